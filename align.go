@@ -3,81 +3,65 @@ package lipgloss
 import (
 	"strings"
 
-	"github.com/muesli/reflow/ansi"
 	"github.com/muesli/termenv"
 )
 
-// Perform text alignment. If the string is multi-lined, we also make all lines
-// the same width by padding them with spaces. If a termenv style is passed,
-// use that to style the spaces added.
-func alignTextHorizontal(str string, pos Position, width int, style *termenv.Style) string {
-	lines, widestLine := getLines(str)
-	var b strings.Builder
-
-	for i, l := range lines {
-		lineWidth := ansi.PrintableRuneWidth(l)
-
-		shortAmount := widestLine - lineWidth                // difference from the widest line
-		shortAmount += max(0, width-(shortAmount+lineWidth)) // difference from the total width, if set
-
-		if shortAmount > 0 {
-			switch pos { //nolint:exhaustive
-			case Right:
-				s := strings.Repeat(" ", shortAmount)
-				if style != nil {
-					s = style.Styled(s)
-				}
-				l = s + l
-			case Center:
-				// Note: remainder goes on the right.
-				left := shortAmount / 2       //nolint:gomnd
-				right := left + shortAmount%2 //nolint:gomnd
-
-				leftSpaces := strings.Repeat(" ", left)
-				rightSpaces := strings.Repeat(" ", right)
-
-				if style != nil {
-					leftSpaces = style.Styled(leftSpaces)
-					rightSpaces = style.Styled(rightSpaces)
-				}
-				l = leftSpaces + l + rightSpaces
-			default: // Left
-				s := strings.Repeat(" ", shortAmount)
-				if style != nil {
-					s = style.Styled(s)
-				}
-				l += s
-			}
-		}
+// alignLine aligns a line of text within the given number of extra cells by
+// padding it with spaces. If a termenv style is passed, use that to style the
+// spaces added.
+func alignLine(l string, shortAmount int, pos Position, style *termenv.Style) string {
+	if shortAmount <= 0 {
+		return l
+	}
 
-		b.WriteString(l)
-		if i < len(lines)-1 {
-			b.WriteRune('\n')
-		}
+	switch pos { //nolint:exhaustive
+	case Right:
+		return styledSpaces(shortAmount, style) + l
+	case Center:
+		// Note: remainder goes on the right.
+		left := shortAmount / 2       //nolint:gomnd
+		right := left + shortAmount%2 //nolint:gomnd
+		return styledSpaces(left, style) + l + styledSpaces(right, style)
+	default: // Left
+		return l + styledSpaces(shortAmount, style)
 	}
+}
 
-	return b.String()
+// styledSpaces returns n spaces, styled with the given termenv style if it
+// isn't nil.
+func styledSpaces(n int, style *termenv.Style) string {
+	s := strings.Repeat(" ", n)
+	if style != nil {
+		s = style.Styled(s)
+	}
+	return s
 }
 
 func alignTextVertical(str string, pos Position, height int, _ *termenv.Style) string {
-	strHeight := strings.Count(str, "\n") + 1
+	top, bottom := verticalPadding(strings.Count(str, "\n")+1, pos, height)
+	return strings.Repeat("\n", top) + str + strings.Repeat("\n", bottom)
+}
+
+// verticalPadding returns the number of blank lines to add above and below a
+// block of text of the given height to align it vertically.
+func verticalPadding(strHeight int, pos Position, height int) (top, bottom int) {
 	if height < strHeight {
-		return str
+		return 0, 0
 	}
 
 	switch pos {
 	case Top:
-		return str + strings.Repeat("\n", height-strHeight)
+		return 0, height - strHeight
 	case Center:
-		var topPadding, bottomPadding = (height - strHeight) / 2, (height - strHeight) / 2
-		if strHeight+topPadding+bottomPadding > height {
-			topPadding--
-		} else if strHeight+topPadding+bottomPadding < height {
-			bottomPadding++
+		top, bottom = (height-strHeight)/2, (height-strHeight)/2
+		if strHeight+top+bottom > height {
+			top--
+		} else if strHeight+top+bottom < height {
+			bottom++
 		}
-		return strings.Repeat("\n", topPadding) + str + strings.Repeat("\n", bottomPadding)
+		return top, bottom
 	case Bottom:
-		return strings.Repeat("\n", height-strHeight) + str
+		return height - strHeight, 0
 	}
-	return str
+	return 0, 0
 }
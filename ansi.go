@@ -0,0 +1,261 @@
+package lipgloss
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+const (
+	sgrReset  = "\x1b[0m"
+	linkReset = "\x1b]8;;\x1b\\"
+)
+
+// printableWidth returns the cell width of a single line of text, measured
+// with the given renderer, ignoring escape sequences. Unlike reflow's
+// ansi.PrintableRuneWidth, this understands OSC sequences, such as
+// hyperlinks, whose payloads aren't printed, and measures grapheme clusters
+// rather than individual runes.
+func printableWidth(r *Renderer, str string) (width int) {
+	// Fast path for text made up of characters that are grapheme clusters of
+	// their own. Anything else is measured from the start, as the character
+	// before it may be part of the same cluster.
+	var cond *runewidth.Condition
+	for i := 0; i < len(str); {
+		switch c := str[i]; {
+		case c == '\x1b':
+			i += len(readEscape(str[i:]))
+		case c < utf8.RuneSelf:
+			width += asciiWidth(c)
+			i++
+		default:
+			ru, n := utf8.DecodeRuneInString(str[i:])
+			if !isSimpleRune(ru) {
+				return printableWidthSlow(r, str)
+			}
+			if cond == nil {
+				cond = r.widthCondition()
+			}
+			width += cond.RuneWidth(ru)
+			i += n
+		}
+	}
+	return width
+}
+
+func printableWidthSlow(r *Renderer, str string) (width int) {
+	seg := newSegmenter(r, str)
+	for seg.next() {
+		width += seg.width
+	}
+	return width
+}
+
+// truncateLine truncates a single line of text to the given cell width,
+// putting tail in place of whatever was cut. The position determines where
+// the line is cut: Right cuts off the end, Left cuts off the start and Center
+// cuts out the middle.
+//
+// Escape sequences don't count towards the width and are kept, so styling
+// carries on as it would have around the cut, and the tail takes on the
+// styling at the point of the cut.
+func truncateLine(r *Renderer, str string, width int, tail string, pos Position) string {
+	total := printableWidth(r, str)
+	if total <= width {
+		return str
+	}
+
+	limit := width - printableWidth(r, tail)
+	if limit < 0 {
+		// There's no room for the whole tail, so it's cut down instead.
+		return truncateLine(r, tail, width, "", Right)
+	}
+
+	// Keep head cells from the start and rear cells from the end.
+	rear := int(math.Round(float64(limit) * (1 - pos.value())))
+	head := limit - rear
+	cut := total - rear
+
+	var (
+		b         strings.Builder
+		state     ansiState
+		current   int
+		wroteTail bool
+		seg       = newSegmenter(r, str)
+	)
+
+	for seg.next() {
+		if seg.esc {
+			state.update(seg.text)
+			b.WriteString(seg.text)
+			continue
+		}
+
+		w := seg.width
+		switch {
+		case current+w <= head, current >= cut:
+			b.WriteString(seg.text)
+		case !wroteTail:
+			b.WriteString(tail)
+			wroteTail = true
+		}
+		current += w
+	}
+
+	b.WriteString(state.close())
+
+	return b.String()
+}
+
+// ansiState tracks the styling and hyperlink active at a given point in a
+// string, so that they can be closed and reopened when the string is broken
+// up into pieces.
+type ansiState struct {
+	sgr  string
+	link string
+}
+
+// update applies an escape sequence to the state. Sequences other than SGR
+// and OSC 8 hyperlinks are ignored.
+func (a *ansiState) update(seq string) {
+	switch {
+	case isSGR(seq):
+		if isSGRReset(seq) {
+			a.sgr = ""
+		} else {
+			a.sgr += seq
+		}
+	case isHyperlink(seq):
+		if hyperlinkURL(seq) == "" {
+			a.link = ""
+		} else {
+			a.link = seq
+		}
+	}
+}
+
+// open returns the sequences that restore the state.
+func (a ansiState) open() string {
+	return a.link + a.sgr
+}
+
+// close returns the sequences that reset the state.
+func (a ansiState) close() string {
+	var s string
+	if a.sgr != "" {
+		s += sgrReset
+	}
+	if a.link != "" {
+		s += linkReset
+	}
+	return s
+}
+
+// isolateLines splits str into lines, closing any styling or hyperlink still
+// active at the end of a line and reopening it at the start of the next, so
+// that each line can be truncated, padded or bordered on its own.
+func isolateLines(str string) []string {
+	var isolator lineIsolator
+	lines := strings.Split(str, "\n")
+	for i, l := range lines {
+		lines[i] = isolator.isolate(l)
+	}
+	return lines
+}
+
+// lineIsolator isolates lines one at a time, for when they're not all at
+// hand at once. See isolateLines.
+type lineIsolator struct {
+	state ansiState
+}
+
+// isolate isolates the next line.
+func (li *lineIsolator) isolate(l string) string {
+	open := li.state.open()
+	if open == "" && !strings.Contains(l, "\x1b") {
+		return l
+	}
+
+	for rest := l; ; {
+		i := strings.IndexByte(rest, '\x1b')
+		if i < 0 {
+			break
+		}
+		seq := readEscape(rest[i:])
+		li.state.update(seq)
+		rest = rest[i+len(seq):]
+	}
+	return open + l + li.state.close()
+}
+
+// readEscape reads a single escape sequence from the start of str.
+func readEscape(str string) string {
+	if len(str) < 2 { //nolint:gomnd
+		return str
+	}
+
+	switch str[1] {
+	case '[':
+		// CSI: terminated by a byte in the range 0x40–0x7E.
+		for i := 2; i < len(str); i++ {
+			if str[i] >= 0x40 && str[i] <= 0x7e {
+				return str[:i+1]
+			}
+		}
+	case ']':
+		// OSC: terminated by BEL or ST.
+		for i := 2; i < len(str); i++ {
+			if str[i] == '\a' {
+				return str[:i+1]
+			}
+			if str[i] == '\x1b' && i+1 < len(str) && str[i+1] == '\\' {
+				return str[:i+2]
+			}
+		}
+	default:
+		_, n := utf8.DecodeRuneInString(str[1:])
+		return str[:1+n]
+	}
+
+	return str
+}
+
+// isSGR reports whether seq is an SGR (Select Graphic Rendition) sequence.
+func isSGR(seq string) bool {
+	return strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "m")
+}
+
+// isSGRReset reports whether seq is an SGR sequence resetting all styling.
+func isSGRReset(seq string) bool {
+	return seq == "\x1b[m" || seq == sgrReset
+}
+
+// hyperlink is an OSC 8 hyperlink.
+type hyperlink struct {
+	url    string
+	params string
+}
+
+// open returns the sequence opening the hyperlink. Close it with linkReset.
+func (h hyperlink) open() string {
+	return "\x1b]8;" + h.params + ";" + h.url + "\x1b\\"
+}
+
+// isHyperlink reports whether seq is an OSC 8 hyperlink sequence.
+func isHyperlink(seq string) bool {
+	return strings.HasPrefix(seq, "\x1b]8;")
+}
+
+// hyperlinkURL returns the URL of an OSC 8 hyperlink sequence. An empty URL
+// closes the current hyperlink.
+func hyperlinkURL(seq string) string {
+	seq = strings.TrimPrefix(seq, "\x1b]8;")
+	seq = strings.TrimSuffix(strings.TrimSuffix(seq, "\a"), "\x1b\\")
+	parts := strings.SplitN(seq, ";", 2) //nolint:gomnd
+	if len(parts) < 2 {                  //nolint:gomnd
+		return ""
+	}
+	return parts[1]
+}
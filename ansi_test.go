@@ -0,0 +1,75 @@
+package lipgloss
+
+import "testing"
+
+func TestTruncateLine(t *testing.T) {
+	tt := []struct {
+		input    string
+		width    int
+		tail     string
+		expected string
+	}{
+		{"hello", 5, "…", "hello"},
+		{"hello", 4, "…", "hel…"},
+		{"hello", 0, "", ""},
+		{"你好吗", 3, "", "你"},
+		{"\x1b[1mhello\x1b[0m", 2, "", "\x1b[1mhe\x1b[0m"},
+		{"\x1b[1mhe\x1b[0mllo", 3, "", "\x1b[1mhe\x1b[0ml"},
+		{"\x1b]8;;https://charm.sh\x1b\\hello\x1b]8;;\x1b\\", 2, "", "\x1b]8;;https://charm.sh\x1b\\he\x1b]8;;\x1b\\"},
+		{"e\u0301e\u0301e\u0301", 2, "", "e\u0301e\u0301"},
+		{"\U0001F468\u200D\U0001F469\u200D\U0001F467ab", 3, "", "\U0001F468\u200D\U0001F469\u200D\U0001F467a"},
+		{"\U0001F1FA\U0001F1F8\U0001F1EC\U0001F1E7", 3, "…", "\U0001F1FA\U0001F1F8…"},
+		{"hello", 1, "...", "."},
+		{"hello", 0, "...", ""},
+	}
+
+	for i, tc := range tt {
+		res := truncateLine(nil, tc.input, tc.width, tc.tail, Right)
+		if res != tc.expected {
+			t.Errorf("Test %d, expected:\n\n`%s`\n\nActual output:\n\n`%s`\n\n",
+				i, formatEscapes(tc.expected), formatEscapes(res))
+		}
+	}
+}
+
+func TestTruncateLinePosition(t *testing.T) {
+	tt := []struct {
+		input    string
+		pos      Position
+		expected string
+	}{
+		{"/usr/local/bin/lipgloss", Right, "/usr/local/bi…"},
+		{"/usr/local/bin/lipgloss", Left, "…/bin/lipgloss"},
+		{"/usr/local/bin/lipgloss", Center, "/usr/l…ipgloss"},
+		{"\x1b[1m/usr/local/bin/lipgloss\x1b[0m", Left, "\x1b[1m…/bin/lipgloss\x1b[0m"},
+	}
+
+	for i, tc := range tt {
+		res := truncateLine(nil, tc.input, 14, "…", tc.pos)
+		if res != tc.expected {
+			t.Errorf("Test %d, expected:\n\n`%s`\n\nActual output:\n\n`%s`\n\n",
+				i, formatEscapes(tc.expected), formatEscapes(res))
+		}
+	}
+}
+
+func TestPrintableWidth(t *testing.T) {
+	requireEqual(t, 5, printableWidth(nil, "\x1b[1mhello\x1b[0m"))
+	requireEqual(t, 5, printableWidth(nil, "\x1b]8;;https://charm.sh\x1b\\hello\x1b]8;;\x1b\\"))
+	requireEqual(t, 4, printableWidth(nil, "你好"))
+
+	// Grapheme clusters.
+	requireEqual(t, 2, printableWidth(nil, "a\u0301b"))
+	requireEqual(t, 2, printableWidth(nil, "\U0001F468\u200D\U0001F469\u200D\U0001F467"))
+	requireEqual(t, 2, printableWidth(nil, "\U0001F1FA\U0001F1F8"))
+	requireEqual(t, 3, printableWidth(nil, "\u2764\ufe0f!"))
+	requireEqual(t, 4, printableWidth(nil, "\x1b[1m\U0001F1FA\U0001F1F8\x1b[0m\U0001F1EC\U0001F1E7"))
+}
+
+func TestIsolateLines(t *testing.T) {
+	res := isolateLines("\x1b[31mfoo\nbar\x1b[0m\nbaz")
+	requireEqual(t, 3, len(res))
+	requireEqual(t, "\x1b[31mfoo\x1b[0m", res[0])
+	requireEqual(t, "\x1b[31mbar\x1b[0m", res[1])
+	requireEqual(t, "baz", res[2])
+}
@@ -0,0 +1,180 @@
+package lipgloss
+
+import (
+	"io"
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+// textBlock is styled text laid out as a block, with padding, alignment and
+// gradients. Its size is worked out up front, and each line is put together
+// when it's asked for, so that the block as a whole never has to be built up
+// in memory.
+type textBlock struct {
+	r      *Renderer
+	lines  []string
+	widths []int
+
+	// Padding. Right padding is left off of an empty block.
+	top, right, bottom, left int
+
+	// Blank lines added above the padding when aligning the block
+	// vertically.
+	offset int
+
+	// The number of lines in the block, and the width of the widest one.
+	height, width int
+
+	aligned bool
+	align   Position
+
+	// The style for whitespace added by padding and alignment, if any.
+	whitespace      termenv.Style
+	styleWhitespace bool
+
+	fg, bg *Gradient
+}
+
+func newTextBlock(r *Renderer, lines []string) textBlock {
+	b := textBlock{
+		r:      r,
+		lines:  lines,
+		widths: make([]int, len(lines)),
+		height: len(lines),
+	}
+	for i, l := range lines {
+		b.widths[i] = printableWidth(r, l)
+		b.width = max(b.width, b.widths[i])
+	}
+	return b
+}
+
+// pad adds padding around the block's text.
+func (b *textBlock) pad(top, right, bottom, left int) {
+	b.top, b.bottom, b.left = top, bottom, left
+	if left > 0 || len(b.lines) > 1 || b.lines[0] != "" {
+		b.right = right
+	}
+	b.height += top + bottom
+	b.width += b.left + b.right
+}
+
+// alignVertical adds blank lines to make the block the given height.
+func (b *textBlock) alignVertical(pos Position, height int) {
+	top, bottom := verticalPadding(b.height, pos, height)
+	b.offset = top
+	b.height += top + bottom
+}
+
+// alignHorizontal aligns the lines of the block, padding short lines so that
+// they're all the same width, and at least the given width.
+func (b *textBlock) alignHorizontal(pos Position, width int) {
+	b.aligned = true
+	b.align = pos
+	b.width = max(b.width, width)
+}
+
+// line returns line y of the block.
+func (b *textBlock) line(y int) string {
+	var (
+		l string
+		w int
+	)
+	var ws *termenv.Style
+	if b.styleWhitespace {
+		ws = &b.whitespace
+	}
+
+	if i := y - b.offset - b.top; i >= 0 && i < len(b.lines) {
+		l, w = b.lines[i], b.widths[i]
+		if b.left > 0 {
+			l = styledSpaces(b.left, ws) + l
+		}
+		if b.right > 0 {
+			l += styledSpaces(b.right, ws)
+		}
+		w += b.left + b.right
+	}
+
+	if b.aligned {
+		l = alignLine(l, b.width-w, b.align, ws)
+	}
+	if b.fg != nil || b.bg != nil {
+		l = gradientLine(b.r, l, y, b.width, b.height, b.fg, b.bg)
+	}
+
+	return l
+}
+
+// blockWriter writes a rendered block to an io.Writer. If a maximum width or
+// height is set, lines are cut down to size as they're written, which means
+// holding on to the line being written until it's complete.
+type blockWriter struct {
+	w io.Writer
+	r *Renderer
+
+	maxWidth, maxHeight int
+	ellipsis            string
+	ellipsisPosition    Position
+
+	line     strings.Builder
+	lines    int
+	isolator lineIsolator
+
+	n   int
+	err error
+}
+
+// WriteString writes a piece of the block, which may span several lines.
+func (b *blockWriter) WriteString(str string) {
+	if b.maxWidth <= 0 && b.maxHeight <= 0 {
+		b.write(str)
+		return
+	}
+
+	for {
+		i := strings.IndexByte(str, '\n')
+		if i < 0 {
+			b.line.WriteString(str)
+			return
+		}
+		b.line.WriteString(str[:i])
+		b.endLine()
+		str = str[i+1:]
+	}
+}
+
+// endLine cuts down the line being written and writes it out.
+func (b *blockWriter) endLine() {
+	l := b.line.String()
+	b.line.Reset()
+
+	if b.maxHeight > 0 && b.lines >= b.maxHeight {
+		return
+	}
+	if b.maxWidth > 0 {
+		l = truncateLine(b.r, b.isolator.isolate(l), b.maxWidth, b.ellipsis, b.ellipsisPosition)
+	}
+	if b.lines > 0 {
+		b.write("\n")
+	}
+	b.write(l)
+	b.lines++
+}
+
+// close writes out the last line, if need be.
+func (b *blockWriter) close() {
+	if b.maxWidth > 0 || b.maxHeight > 0 {
+		b.endLine()
+	}
+}
+
+func (b *blockWriter) write(str string) {
+	if b.err != nil || str == "" {
+		return
+	}
+	n, err := io.WriteString(b.w, str)
+	b.n += n
+	b.err = err
+}
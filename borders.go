@@ -1,10 +1,9 @@
 package lipgloss
 
 import (
+	"math"
 	"strings"
 
-	"github.com/mattn/go-runewidth"
-	"github.com/muesli/reflow/ansi"
 	"github.com/muesli/termenv"
 )
 
@@ -56,7 +55,7 @@ func (b Border) GetLeftSize() int {
 
 func getBorderEdgeWidth(borderParts ...string) (maxWidth int) {
 	for _, piece := range borderParts {
-		w := maxRuneWidth(piece)
+		w := maxGraphemeWidth(nil, piece)
 		if w > maxWidth {
 			maxWidth = w
 		}
@@ -226,7 +225,30 @@ func HiddenBorder() Border {
 	return hiddenBorder
 }
 
-func (s Style) applyBorder(str string) string {
+// borderFrame draws a border around a block of text, a line at a time.
+type borderFrame struct {
+	s      Style
+	border Border
+
+	hasTop, hasRight, hasBottom, hasLeft bool
+
+	leftFG, rightFG TerminalColor
+	leftBG, rightBG TerminalColor
+
+	// The size of the bordered block, for positioning gradients.
+	box borderBox
+
+	top, bottom           string
+	leftChars, rightChars []string
+
+	// Close styles spanning multiple lines before the right edge, and reopen
+	// them after the left, so that they don't bleed into the border.
+	isolator lineIsolator
+}
+
+// borderFrame returns the style's border for a block of text of the given
+// size, or false if the style has no border to draw.
+func (s Style) borderFrame(width, height int) (*borderFrame, bool) {
 	var (
 		topSet    = s.isSet(borderTopKey)
 		rightSet  = s.isSet(borderRightKey)
@@ -240,14 +262,10 @@ func (s Style) applyBorder(str string) string {
 		hasLeft   = s.getAsBool(borderLeftKey, false)
 
 		topFG    = s.getAsColor(borderTopForegroundKey)
-		rightFG  = s.getAsColor(borderRightForegroundKey)
 		bottomFG = s.getAsColor(borderBottomForegroundKey)
-		leftFG   = s.getAsColor(borderLeftForegroundKey)
 
 		topBG    = s.getAsColor(borderTopBackgroundKey)
-		rightBG  = s.getAsColor(borderRightBackgroundKey)
 		bottomBG = s.getAsColor(borderBottomBackgroundKey)
-		leftBG   = s.getAsColor(borderLeftBackgroundKey)
 	)
 
 	// If a border is set and no sides have been specifically turned on or off
@@ -261,16 +279,14 @@ func (s Style) applyBorder(str string) string {
 
 	// If no border is set or all borders are been disabled, abort.
 	if border == noBorder || (!hasTop && !hasRight && !hasBottom && !hasLeft) {
-		return str
+		return nil, false
 	}
 
-	lines, width := getLines(str)
-
 	if hasLeft {
 		if border.Left == "" {
 			border.Left = " "
 		}
-		width += maxRuneWidth(border.Left)
+		width += maxGraphemeWidth(s.r, border.Left)
 	}
 
 	if hasRight && border.Right == "" {
@@ -317,65 +333,116 @@ func (s Style) applyBorder(str string) string {
 		}
 	}
 
-	// For now, limit corners to one rune.
-	border.TopLeft = getFirstRuneAsString(border.TopLeft)
-	border.TopRight = getFirstRuneAsString(border.TopRight)
-	border.BottomRight = getFirstRuneAsString(border.BottomRight)
-	border.BottomLeft = getFirstRuneAsString(border.BottomLeft)
-
-	var out strings.Builder
+	// For now, limit corners to one character.
+	border.TopLeft = getFirstGraphemeAsString(border.TopLeft)
+	border.TopRight = getFirstGraphemeAsString(border.TopRight)
+	border.BottomRight = getFirstGraphemeAsString(border.BottomRight)
+	border.BottomLeft = getFirstGraphemeAsString(border.BottomLeft)
+
+	f := &borderFrame{
+		s:          s,
+		border:     border,
+		hasTop:     hasTop,
+		hasRight:   hasRight,
+		hasBottom:  hasBottom,
+		hasLeft:    hasLeft,
+		leftFG:     s.getAsColor(borderLeftForegroundKey),
+		rightFG:    s.getAsColor(borderRightForegroundKey),
+		leftBG:     s.getAsColor(borderLeftBackgroundKey),
+		rightBG:    s.getAsColor(borderRightBackgroundKey),
+		box:        borderBox{width: width, height: height},
+		leftChars:  graphemes(border.Left),
+		rightChars: graphemes(border.Right),
+	}
+	if hasRight {
+		f.box.width += maxGraphemeWidth(s.r, border.Right)
+	}
+	if hasTop {
+		f.box.height++
+	}
+	if hasBottom {
+		f.box.height++
+	}
 
 	// Render top
 	if hasTop {
-		top := renderHorizontalEdge(border.TopLeft, border.Top, border.TopRight, width)
-		top = s.styleBorder(top, topFG, topBG)
-		out.WriteString(top)
-		out.WriteRune('\n')
-	}
-
-	leftRunes := []rune(border.Left)
-	leftIndex := 0
-
-	rightRunes := []rune(border.Right)
-	rightIndex := 0
-
-	// Render sides
-	for i, l := range lines {
-		if hasLeft {
-			r := string(leftRunes[leftIndex])
-			leftIndex++
-			if leftIndex >= len(leftRunes) {
-				leftIndex = 0
-			}
-			out.WriteString(s.styleBorder(r, leftFG, leftBG))
-		}
-		out.WriteString(l)
-		if hasRight {
-			r := string(rightRunes[rightIndex])
-			rightIndex++
-			if rightIndex >= len(rightRunes) {
-				rightIndex = 0
-			}
-			out.WriteString(s.styleBorder(r, rightFG, rightBG))
-		}
-		if i < len(lines)-1 {
-			out.WriteRune('\n')
+		if title := s.getAsString(borderTitleKey); title != "" {
+			f.top = s.renderLabeledEdge(border.TopLeft, border.Top, border.TopRight, width,
+				title, s.getAsPosition(borderTitleAlignKey), s.getAsStyle(borderTitleStyleKey),
+				topFG, topBG, f.box.row(0))
+		} else {
+			f.top = renderHorizontalEdge(s.r, border.TopLeft, border.Top, border.TopRight, width)
+			f.top = s.styleBorderAt(f.top, topFG, topBG, f.box.row(0))
 		}
 	}
 
 	// Render bottom
 	if hasBottom {
-		bottom := renderHorizontalEdge(border.BottomLeft, border.Bottom, border.BottomRight, width)
-		bottom = s.styleBorder(bottom, bottomFG, bottomBG)
-		out.WriteRune('\n')
-		out.WriteString(bottom)
+		if footer := s.getAsString(borderFooterKey); footer != "" {
+			f.bottom = s.renderLabeledEdge(border.BottomLeft, border.Bottom, border.BottomRight, width,
+				footer, s.getAsPosition(borderFooterAlignKey), s.getAsStyle(borderFooterStyleKey),
+				bottomFG, bottomBG, f.box.row(f.box.height-1))
+		} else {
+			f.bottom = renderHorizontalEdge(s.r, border.BottomLeft, border.Bottom, border.BottomRight, width)
+			f.bottom = s.styleBorderAt(f.bottom, bottomFG, bottomBG, f.box.row(f.box.height-1))
+		}
 	}
 
-	return out.String()
+	return f, true
+}
+
+// side returns the i-th line of the block with its left and right borders.
+// Lines must be passed in order.
+func (f *borderFrame) side(i int, l string) string {
+	l = f.isolator.isolate(l)
+
+	row := f.box.row(i)
+	if f.hasTop {
+		row = f.box.row(i + 1)
+	}
+
+	var left, right string
+	if f.hasLeft {
+		c := f.leftChars[i%len(f.leftChars)]
+		left = f.s.styleBorderAt(c, f.leftFG, f.leftBG, row)
+	}
+	if f.hasRight {
+		c := f.rightChars[i%len(f.rightChars)]
+		right = f.s.styleBorderAt(c, f.rightFG, f.rightBG, row.at(f.box.width-printableWidth(f.s.r, c)))
+	}
+	return left + l + right
+}
+
+// width returns the width of the widest line of the bordered block, given
+// the width of the lines within it.
+func (f *borderFrame) width(inner int) int {
+	width := max(printableWidth(f.s.r, f.top), printableWidth(f.s.r, f.bottom))
+
+	// The characters on either side repeat, so we only need to look at as many
+	// lines as it takes for them to line up again.
+	lines := f.box.height
+	if f.hasTop {
+		lines--
+	}
+	if f.hasBottom {
+		lines--
+	}
+	lines = min(lines, max(1, len(f.leftChars))*max(1, len(f.rightChars)))
+	for i := 0; i < lines; i++ {
+		w := inner
+		if f.hasLeft {
+			w += printableWidth(f.s.r, f.leftChars[i%len(f.leftChars)])
+		}
+		if f.hasRight {
+			w += printableWidth(f.s.r, f.rightChars[i%len(f.rightChars)])
+		}
+		width = max(width, w)
+	}
+	return width
 }
 
 // Render the horizontal (top or bottom) portion of a border.
-func renderHorizontalEdge(left, middle, right string, width int) string {
+func renderHorizontalEdge(r *Renderer, left, middle, right string, width int) string {
 	if width < 1 {
 		return ""
 	}
@@ -384,27 +451,99 @@ func renderHorizontalEdge(left, middle, right string, width int) string {
 		middle = " "
 	}
 
-	leftWidth := ansi.PrintableRuneWidth(left)
-	rightWidth := ansi.PrintableRuneWidth(right)
+	leftWidth := printableWidth(r, left)
+	rightWidth := printableWidth(r, right)
 
-	runes := []rune(middle)
+	chars := graphemes(middle)
+	widths := make([]int, len(chars))
+	for k, c := range chars {
+		widths[k] = printableWidth(r, c)
+	}
 	j := 0
 
 	out := strings.Builder{}
 	out.WriteString(left)
 	for i := leftWidth + rightWidth; i < width+rightWidth; {
-		out.WriteRune(runes[j])
+		out.WriteString(chars[j])
 		j++
-		if j >= len(runes) {
+		if j >= len(chars) {
 			j = 0
 		}
-		i += ansi.PrintableRuneWidth(string(runes[j]))
+		i += widths[j]
 	}
 	out.WriteString(right)
 
 	return out.String()
 }
 
+// Render the horizontal (top or bottom) portion of a border with a label
+// embedded in it. The label is rendered with its own style and truncated if
+// there isn't enough room for it. Only the first line of the label is used.
+func (s Style) renderLabeledEdge(left, middle, right string, width int, label string, pos Position, labelStyle Style, fg, bg TerminalColor, at borderPos) string {
+	if width < 1 {
+		return ""
+	}
+
+	inner := width - printableWidth(s.r, left)
+	if inner < 1 {
+		return s.styleBorderAt(renderHorizontalEdge(s.r, left, middle, right, width), fg, bg, at)
+	}
+
+	if labelStyle.r == nil {
+		labelStyle.r = s.r
+	}
+	label = labelStyle.Render(strings.SplitN(label, "\n", 2)[0])
+	label = strings.SplitN(label, "\n", 2)[0]
+	if printableWidth(s.r, label) > inner {
+		label = truncateLine(s.r, label, inner, "…", Right)
+	}
+
+	// Note: when centering, the remainder goes on the right.
+	gap := inner - printableWidth(s.r, label)
+	rightGap := int(math.Round(float64(gap) * (1 - pos.value())))
+	leftGap := gap - rightGap
+
+	before := left + renderHorizontalFill(s.r, middle, leftGap)
+	return s.styleBorderAt(before, fg, bg, at) +
+		label +
+		s.styleBorderAt(renderHorizontalFill(s.r, middle, rightGap)+right, fg, bg,
+			at.at(printableWidth(s.r, before)+printableWidth(s.r, label)))
+}
+
+// Fill the given number of cells by cycling through the characters in str.
+// Gaps left by characters wider than one cell are filled with spaces.
+func renderHorizontalFill(r *Renderer, str string, width int) string {
+	if width < 1 {
+		return ""
+	}
+	if str == "" {
+		str = " "
+	}
+
+	chars := graphemes(str)
+	j := 0
+
+	var b strings.Builder
+	for i := 0; i < width; {
+		w := printableWidth(r, chars[j])
+		if i+w > width {
+			break
+		}
+		b.WriteString(chars[j])
+		i += max(1, w)
+		j++
+		if j >= len(chars) {
+			j = 0
+		}
+	}
+
+	if short := width - printableWidth(r, b.String()); short > 0 {
+		b.WriteString(strings.Repeat(" ", short))
+	}
+
+	return b.String()
+}
+
 // Apply foreground and background styling to a border.
 func (s Style) styleBorder(border string, fg, bg TerminalColor) string {
 	if fg == noColor && bg == noColor {
@@ -423,20 +562,102 @@ func (s Style) styleBorder(border string, fg, bg TerminalColor) string {
 	return style.Styled(border)
 }
 
-func maxRuneWidth(str string) (width int) {
-	for _, r := range str {
-		w := runewidth.RuneWidth(r)
-		if w > width {
-			width = w
+// borderBox is the size of a bordered block, which gradient border colors
+// are spread across.
+type borderBox struct {
+	width, height int
+}
+
+// row returns the position of the start of the given row.
+func (b borderBox) row(y int) borderPos {
+	return borderPos{box: b, y: y}
+}
+
+// borderPos is the position of a piece of border within a bordered block.
+type borderPos struct {
+	box  borderBox
+	x, y int
+}
+
+// at returns the position x cells into the row.
+func (p borderPos) at(x int) borderPos {
+	p.x = x
+	return p
+}
+
+// Apply foreground and background styling to a piece of border at the given
+// position. Gradients are resolved cell by cell; plain colors are applied to
+// the piece as a whole.
+func (s Style) styleBorderAt(border string, fg, bg TerminalColor, at borderPos) string {
+	fgGradient, bgGradient := asGradient(fg), asGradient(bg)
+	if fgGradient == nil && bgGradient == nil {
+		return s.styleBorder(border, fg, bg)
+	}
+
+	// Runs of cells sharing the same colors are styled together. Colors are
+	// told apart by the sequences they resolve to, as some colors, such as
+	// those derived from gradients, can't be compared directly.
+	var (
+		b                strings.Builder
+		piece            strings.Builder
+		pieceFG, pieceBG TerminalColor
+		pieceSeq         string
+		fgSeq, bgSeq     = s.colorSequence(fg, false), s.colorSequence(bg, true)
+		x                = at.x
+		seg              = newSegmenter(s.r, border)
+	)
+	for seg.next() {
+		cellFG, cellBG := fg, bg
+		if fgGradient != nil {
+			cellFG = fgGradient.at(s.r, fgGradient.position(x, at.y, at.box.width, at.box.height))
+			fgSeq = s.colorSequence(cellFG, false)
+		}
+		if bgGradient != nil {
+			cellBG = bgGradient.at(s.r, bgGradient.position(x, at.y, at.box.width, at.box.height))
+			bgSeq = s.colorSequence(cellBG, true)
+		}
+
+		seq := fgSeq + ";" + bgSeq
+		if piece.Len() > 0 && seq != pieceSeq {
+			b.WriteString(s.styleBorder(piece.String(), pieceFG, pieceBG))
+			piece.Reset()
+		}
+		piece.WriteString(seg.text)
+		pieceFG, pieceBG, pieceSeq = cellFG, cellBG, seq
+		x += seg.width
+	}
+	if piece.Len() > 0 {
+		b.WriteString(s.styleBorder(piece.String(), pieceFG, pieceBG))
+	}
+
+	return b.String()
+}
+
+// colorSequence returns the sequence that sets c as the foreground or
+// background color, which is empty for no color.
+func (s Style) colorSequence(c TerminalColor, isBg bool) string {
+	if c == nil || c == noColor {
+		return ""
+	}
+	return c.color(s.r).Sequence(isBg)
+}
+
+// maxGraphemeWidth returns the width of the widest character in str.
+func maxGraphemeWidth(r *Renderer, str string) (width int) {
+	seg := newSegmenter(r, str)
+	for seg.next() {
+		if seg.width > width {
+			width = seg.width
 		}
 	}
 	return width
 }
 
-func getFirstRuneAsString(str string) string {
+// getFirstGraphemeAsString returns the first character of str, along with
+// any combining marks and the like that go with it.
+func getFirstGraphemeAsString(str string) string {
 	if str == "" {
 		return str
 	}
-	r := []rune(str)
-	return string(r[0])
+	return graphemes(str)[0]
 }
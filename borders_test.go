@@ -0,0 +1,113 @@
+package lipgloss
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestBorderLabels(t *testing.T) {
+	tt := []struct {
+		name     string
+		style    Style
+		expected string
+	}{
+		{
+			name: "title left",
+			style: NewStyle().
+				Border(RoundedBorder()).
+				BorderTitle(" Logs "),
+			expected: strings.Join([]string{
+				"╭ Logs ─────╮",
+				"│hello world│",
+				"╰───────────╯",
+			}, "\n"),
+		},
+		{
+			name: "title center",
+			style: NewStyle().
+				Border(NormalBorder()).
+				BorderTitle("ab").
+				BorderTitleAlign(Center),
+			expected: strings.Join([]string{
+				"┌────ab─────┐",
+				"│hello world│",
+				"└───────────┘",
+			}, "\n"),
+		},
+		{
+			name: "footer right",
+			style: NewStyle().
+				Border(NormalBorder()).
+				BorderFooter("1/3").
+				BorderFooterAlign(Right),
+			expected: strings.Join([]string{
+				"┌───────────┐",
+				"│hello world│",
+				"└────────1/3┘",
+			}, "\n"),
+		},
+		{
+			name: "truncated title",
+			style: NewStyle().
+				Border(NormalBorder()).
+				BorderTitle("a very long title indeed"),
+			expected: strings.Join([]string{
+				"┌a very lon…┐",
+				"│hello world│",
+				"└───────────┘",
+			}, "\n"),
+		},
+		{
+			name: "title without top border",
+			style: NewStyle().
+				Border(NormalBorder(), false, true, true, true).
+				BorderTitle("ignored"),
+			expected: strings.Join([]string{
+				"│hello world│",
+				"└───────────┘",
+			}, "\n"),
+		},
+		{
+			name: "title padding",
+			style: NewStyle().
+				Border(NormalBorder()).
+				BorderTitle("Logs").
+				BorderTitleStyle(NewStyle().Padding(0, 1)),
+			expected: strings.Join([]string{
+				"┌ Logs ─────┐",
+				"│hello world│",
+				"└───────────┘",
+			}, "\n"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := tc.style.Render("hello world")
+			if res != tc.expected {
+				t.Errorf("Expected:\n\n%s\n\nActual output:\n\n%s\n\n", tc.expected, res)
+			}
+		})
+	}
+}
+
+func TestBorderLabelStyle(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.ANSI)
+
+	s := r.NewStyle().
+		Border(NormalBorder(), true, false, false, false).
+		BorderForeground(Color("1")).
+		BorderTitle("x").
+		BorderTitleStyle(r.NewStyle().Bold(true))
+
+	expected := "\x1b[31m\x1b[0m\x1b[1mx\x1b[0m\x1b[31m──\x1b[0m\nabc"
+	res := s.Render("abc")
+	if res != expected {
+		t.Errorf("Expected:\n\n`%s`\n\nActual output:\n\n`%s`\n\n",
+			formatEscapes(expected), formatEscapes(res))
+	}
+}
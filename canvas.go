@@ -0,0 +1,310 @@
+package lipgloss
+
+import (
+	"sort"
+	"strings"
+)
+
+// Layer is a block of rendered text positioned on a Canvas. Layers with a
+// higher z-index are drawn on top of layers with a lower one; layers with the
+// same z-index are drawn in the order they were added. Tabs in a layer are
+// expanded to 4 spaces, as in styles.
+//
+// Example:
+//
+//	dialog := lipgloss.NewLayer(dialogStyle.Render("Are you sure?")).X(10).Y(4).Z(1)
+type Layer struct {
+	content string
+	x, y, z int
+}
+
+// NewLayer returns a new Layer with the given content positioned at the
+// origin.
+func NewLayer(content string) *Layer {
+	return &Layer{content: content}
+}
+
+// X sets the horizontal position of the layer, in cells.
+func (l *Layer) X(x int) *Layer {
+	l.x = x
+	return l
+}
+
+// Y sets the vertical position of the layer, in cells.
+func (l *Layer) Y(y int) *Layer {
+	l.y = y
+	return l
+}
+
+// Z sets the z-index of the layer.
+func (l *Layer) Z(z int) *Layer {
+	l.z = z
+	return l
+}
+
+// Content sets the content of the layer.
+func (l *Layer) Content(content string) *Layer {
+	l.content = content
+	return l
+}
+
+// GetX returns the horizontal position of the layer.
+func (l *Layer) GetX() int {
+	return l.x
+}
+
+// GetY returns the vertical position of the layer.
+func (l *Layer) GetY() int {
+	return l.y
+}
+
+// GetZ returns the z-index of the layer.
+func (l *Layer) GetZ() int {
+	return l.z
+}
+
+// GetContent returns the content of the layer.
+func (l *Layer) GetContent() string {
+	return l.content
+}
+
+// Canvas composites layers of rendered text on top of one another. Unlike
+// JoinHorizontal, JoinVertical and Place, layers can overlap, which makes a
+// Canvas useful for drawing dialogs, tooltips and the like on top of an
+// existing view.
+//
+// Compositing happens cell by cell. Each layer is opaque only where it has
+// characters: text past the end of a short line is transparent and shows the
+// layers beneath it. Styling on lower layers is preserved around the areas
+// that are covered, and wide characters that are partially covered are
+// replaced with spaces.
+//
+// Example:
+//
+//	c := lipgloss.NewCanvas(
+//	    lipgloss.NewLayer(view),
+//	    lipgloss.NewLayer(dialog).X(10).Y(4).Z(1),
+//	)
+//	fmt.Println(c.Render())
+type Canvas struct {
+	layers []*Layer
+	width  int
+	height int
+}
+
+// NewCanvas returns a new Canvas with the given layers.
+func NewCanvas(layers ...*Layer) *Canvas {
+	return &Canvas{layers: layers}
+}
+
+// AddLayers adds layers to the canvas.
+func (c *Canvas) AddLayers(layers ...*Layer) *Canvas {
+	c.layers = append(c.layers, layers...)
+	return c
+}
+
+// Width sets a fixed width for the canvas. Layers extending beyond it are
+// clipped. A width of 0, the default, sizes the canvas to fit its layers.
+func (c *Canvas) Width(w int) *Canvas {
+	c.width = max(0, w)
+	return c
+}
+
+// Height sets a fixed height for the canvas. Layers extending beyond it are
+// clipped. A height of 0, the default, sizes the canvas to fit its layers.
+func (c *Canvas) Height(h int) *Canvas {
+	c.height = max(0, h)
+	return c
+}
+
+// String returns the composited canvas as a string.
+func (c *Canvas) String() string {
+	return c.Render()
+}
+
+// Render composites the layers on the canvas and returns the result. Unless
+// a fixed size has been set, the result is as wide and as tall as needed to
+// fit every layer. Every line is padded to the same width and anything
+// positioned at negative coordinates is clipped.
+func (c *Canvas) Render() string {
+	if len(c.layers) == 0 && (c.width == 0 || c.height == 0) {
+		return ""
+	}
+
+	layers := make([]*Layer, len(c.layers))
+	copy(layers, c.layers)
+	sort.SliceStable(layers, func(i, j int) bool {
+		return layers[i].z < layers[j].z
+	})
+
+	// Tabs are expanded to spaces, as styles do by default, so that they
+	// take up cells like the rest of the content.
+	contents := make([]string, len(layers))
+	var width, height int
+	for i, l := range layers {
+		contents[i] = convertTabs(l.content, tabWidthDefault)
+		w, h := Size(contents[i])
+		width = max(width, l.x+w)
+		height = max(height, l.y+h)
+	}
+	if c.width > 0 {
+		width = c.width
+	}
+	if c.height > 0 {
+		height = c.height
+	}
+	if width < 1 || height < 1 {
+		return ""
+	}
+
+	grid := make([][]cell, height)
+	for y := range grid {
+		grid[y] = make([]cell, width)
+		for x := range grid[y] {
+			grid[y][x] = blankCell
+		}
+	}
+
+	for j, l := range layers {
+		for i, line := range strings.Split(contents[j], "\n") {
+			y := l.y + i
+			if y < 0 || y >= height {
+				continue
+			}
+			row := grid[y]
+			x := l.x
+			for _, c := range parseCells(line) {
+				drawCell(row, x, c)
+				x += max(1, c.width)
+			}
+		}
+	}
+
+	var b strings.Builder
+	for y, row := range grid {
+		var style, link string
+		for _, c := range row {
+			if c.width == 0 {
+				// Trailing half of a wide character.
+				continue
+			}
+			if c.link != link {
+				if link != "" {
+					b.WriteString(linkReset)
+				}
+				b.WriteString(c.link)
+				link = c.link
+			}
+			if c.style != style {
+				if style != "" {
+					b.WriteString(sgrReset)
+				}
+				b.WriteString(c.style)
+				style = c.style
+			}
+			b.WriteString(c.content)
+		}
+		if style != "" {
+			b.WriteString(sgrReset)
+		}
+		if link != "" {
+			b.WriteString(linkReset)
+		}
+		if y < len(grid)-1 {
+			b.WriteRune('\n')
+		}
+	}
+
+	return b.String()
+}
+
+// cell is a single terminal cell along with the styling active at the point
+// it was drawn. Wide characters take up two cells: the first carries the
+// content and the second is a placeholder with a width of zero.
+type cell struct {
+	content string
+	width   int
+	style   string
+	link    string
+}
+
+var blankCell = cell{content: " ", width: 1}
+
+// drawCell draws c onto row at x, taking care of any wide characters that get
+// partially overwritten in the process.
+func drawCell(row []cell, x int, c cell) {
+	for i := 0; i < max(1, c.width); i++ {
+		if x+i < 0 || x+i >= len(row) {
+			continue
+		}
+		clearWide(row, x+i)
+	}
+
+	switch {
+	case x < 0 && x+c.width > 0:
+		// The leading half was clipped, so fill the visible half with a
+		// space.
+		row[x+1] = cell{content: " ", width: 1, style: c.style, link: c.link}
+	case x < 0 || x >= len(row):
+		return
+	case c.width > 1 && x+1 >= len(row):
+		// The trailing half doesn't fit, so fill the leading half with a
+		// space.
+		row[x] = cell{content: " ", width: 1, style: c.style, link: c.link}
+	default:
+		row[x] = c
+		for i := 1; i < c.width; i++ {
+			row[x+i] = cell{style: c.style, link: c.link}
+		}
+	}
+}
+
+// clearWide replaces a wide character occupying the cell at x with spaces,
+// so that drawing over one half of it doesn't leave the other half dangling.
+func clearWide(row []cell, x int) {
+	c := row[x]
+	switch {
+	case c.width == 0:
+		start := x
+		for start > 0 && row[start].width == 0 {
+			start--
+		}
+		for i := start; i < start+max(1, row[start].width) && i < len(row); i++ {
+			row[i] = cell{content: " ", width: 1, style: row[start].style, link: row[start].link}
+		}
+	case c.width > 1:
+		for i := x; i < x+c.width && i < len(row); i++ {
+			row[i] = cell{content: " ", width: 1, style: c.style, link: c.link}
+		}
+	}
+}
+
+// parseCells breaks a single line of text into cells, recording the SGR
+// styling and hyperlink active for each of them. Escape sequences other than
+// SGR and OSC 8 hyperlinks are dropped. Like Width, cells are measured with
+// the default renderer.
+func parseCells(line string) []cell {
+	var (
+		cells []cell
+		state ansiState
+		seg   = newSegmenter(nil, line)
+	)
+
+	for seg.next() {
+		if seg.esc {
+			state.update(seg.text)
+			continue
+		}
+
+		if seg.width == 0 {
+			if len(cells) > 0 && seg.text[0] >= ' ' {
+				cells[len(cells)-1].content += seg.text
+			}
+			continue
+		}
+
+		cells = append(cells, cell{content: seg.text, width: seg.width, style: state.sgr, link: state.link})
+	}
+
+	return cells
+}
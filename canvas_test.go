@@ -0,0 +1,120 @@
+package lipgloss
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCanvas(t *testing.T) {
+	base := strings.Join([]string{
+		"..........",
+		"..........",
+		"..........",
+	}, "\n")
+
+	tt := []struct {
+		name     string
+		canvas   *Canvas
+		expected string
+	}{
+		{
+			name:     "empty",
+			canvas:   NewCanvas(),
+			expected: "",
+		},
+		{
+			name: "overlay",
+			canvas: NewCanvas(
+				NewLayer(base),
+				NewLayer("ab\ncd").X(3).Y(1),
+			),
+			expected: "..........\n...ab.....\n...cd.....",
+		},
+		{
+			name: "z-index",
+			canvas: NewCanvas(
+				NewLayer("xx").X(1).Y(1).Z(2),
+				NewLayer("yyy").X(1).Y(1).Z(1),
+				NewLayer(base),
+			),
+			expected: "..........\n.xxy......\n..........",
+		},
+		{
+			name: "grows to fit",
+			canvas: NewCanvas(
+				NewLayer("ab"),
+				NewLayer("cd").X(3).Y(1),
+			),
+			expected: "ab   \n   cd",
+		},
+		{
+			name: "short lines are transparent",
+			canvas: NewCanvas(
+				NewLayer(base),
+				NewLayer("a\nbcd"),
+			),
+			expected: "a.........\nbcd.......\n..........",
+		},
+		{
+			name: "negative coordinates are clipped",
+			canvas: NewCanvas(
+				NewLayer(base),
+				NewLayer("abc\ndef").X(-1).Y(-1),
+			),
+			expected: "ef........\n..........\n..........",
+		},
+		{
+			name: "fixed size",
+			canvas: NewCanvas(
+				NewLayer("abc\ndef\nghi"),
+			).Width(2).Height(4),
+			expected: "ab\nde\ngh\n  ",
+		},
+		{
+			name: "tabs are expanded",
+			canvas: NewCanvas(
+				NewLayer(base),
+				NewLayer("a\tb").Y(1),
+			),
+			expected: "..........\na    b....\n..........",
+		},
+		{
+			name: "wide rune split by overlay",
+			canvas: NewCanvas(
+				NewLayer("你好吗"),
+				NewLayer("x").X(3),
+			),
+			expected: "你 x吗",
+		},
+		{
+			name: "wide rune in overlay split at edge",
+			canvas: NewCanvas(
+				NewLayer("...."),
+				NewLayer("你").X(-1),
+				NewLayer("好").X(3),
+			).Width(4),
+			expected: " .. ",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := tc.canvas.Render()
+			if res != tc.expected {
+				t.Errorf("Expected:\n\n%q\n\nActual output:\n\n%q\n\n", tc.expected, res)
+			}
+		})
+	}
+}
+
+func TestCanvasPreservesStyles(t *testing.T) {
+	base := "\x1b[31mabcdef\x1b[0m"
+	overlay := "\x1b[1mXY\x1b[0m"
+
+	expected := "\x1b[31mab\x1b[0m\x1b[1mXY\x1b[0m\x1b[31mef\x1b[0m"
+	res := NewCanvas(NewLayer(base), NewLayer(overlay).X(2)).Render()
+	if res != expected {
+		t.Errorf("Expected:\n\n`%s`\n\nActual output:\n\n`%s`\n\n",
+			formatEscapes(expected), formatEscapes(res))
+	}
+}
@@ -2,6 +2,8 @@ package lipgloss
 
 import (
 	"image/color"
+	"io"
+	"math"
 	"testing"
 
 	"github.com/muesli/termenv"
@@ -87,6 +89,73 @@ func TestHexToColor(t *testing.T) {
 	}
 }
 
+func TestColorManipulation(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.TrueColor)
+	r.SetHasDarkBackground(true)
+
+	tt := []struct {
+		name     string
+		color    TerminalColor
+		expected termenv.Color
+	}{
+		{"lighten", Lighten(Color("#5A56E0"), 0.1), termenv.RGBColor("#796ffd")},
+		{"darken", Darken(Color("#5A56E0"), 0.1), termenv.RGBColor("#373ec3")},
+		{"blend", Blend(Color("#ff0000"), Color("#0000ff"), 0.5), termenv.RGBColor("#be0090")},
+		{"blend start", Blend(Color("#ff0000"), Color("#0000ff"), -1), termenv.RGBColor("#ff0000")},
+		{"complementary", Complementary(Color("#ff0000")), termenv.RGBColor("#00ffff")},
+		{"alpha", Alpha(Color("#ffffff"), Color("#000000"), 0.5), termenv.RGBColor("#808080")},
+		{"alpha over default background", Alpha(Color("#ffffff"), NoColor{}, 0.5), termenv.RGBColor("#808080")},
+		{"adaptive", Darken(AdaptiveColor{Light: "#000000", Dark: "#ffffff"}, 0.5), termenv.RGBColor("#777777")},
+		{"no color", Lighten(NoColor{}, 0.5), termenv.NoColor{}},
+		{"enough contrast", EnsureContrast(Color("#000000"), Color("#ffffff"), 4.5), termenv.RGBColor("#000000")},
+		{"darkened for contrast", EnsureContrast(Color("#777777"), Color("#ffffff"), 4.5), termenv.RGBColor("#767677")},
+		{"lightened for contrast", EnsureContrast(Color("#777777"), Color("#000000"), 7), termenv.RGBColor("#959594")},
+		{"unreachable contrast", EnsureContrast(Color("#777777"), Color("#777777"), 21), termenv.RGBColor("#000000")},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := tc.color.color(r)
+			if res != tc.expected {
+				t.Errorf("Expected %#v, got %#v", tc.expected, res)
+			}
+		})
+	}
+}
+
+func TestColorManipulationProfile(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.ANSI256)
+
+	res := Blend(Color("#ff0000"), Color("#0000ff"), 0.5).color(r)
+	if expected := termenv.ANSI256Color(126); res != expected {
+		t.Errorf("Expected %#v, got %#v", expected, res)
+	}
+}
+
+func TestContrastRatio(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetHasDarkBackground(true)
+
+	tt := []struct {
+		fg, bg   TerminalColor
+		expected float64
+	}{
+		{Color("#000000"), Color("#ffffff"), 21},
+		{Color("#ffffff"), Color("#000000"), 21},
+		{Color("#777777"), Color("#777777"), 1},
+		{NoColor{}, NoColor{}, 21},
+	}
+
+	for i, tc := range tt {
+		res := r.ContrastRatio(tc.fg, tc.bg)
+		if math.Abs(res-tc.expected) > 0.001 {
+			t.Errorf("Test %d: expected %f, got %f", i, tc.expected, res)
+		}
+	}
+}
+
 func TestRGBA(t *testing.T) {
 	tt := []struct {
 		profile  termenv.Profile
@@ -0,0 +1,297 @@
+package lipgloss
+
+import (
+	"math"
+
+	"github.com/lucasb-eyer/go-colorful"
+	"github.com/muesli/termenv"
+)
+
+// The functions below derive new colors from existing ones. The colors they
+// return are worked out when they're rendered, so adaptive colors stay
+// adaptive and the results are degraded to the renderer's color profile like
+// any other color:
+//
+//	base := lipgloss.AdaptiveColor{Light: "#5A56E0", Dark: "#7571F9"}
+//	hover := lipgloss.Lighten(base, 0.1)
+//	disabled := lipgloss.Alpha(base, lipgloss.Color("#1c1c1c"), 0.4)
+//
+// The absence of a color can't be manipulated, so manipulating NoColor
+// results in NoColor.
+
+// Lighten returns a color lighter than c by the given amount, which is a
+// fraction of the full lightness range: an amount of 0.1 adds 10% to the
+// color's perceived lightness, as measured in the CIE L*a*b* color space.
+func Lighten(c TerminalColor, amount float64) TerminalColor {
+	return lightenedColor{c: c, amount: amount}
+}
+
+// Darken returns a color darker than c by the given amount, which is a
+// fraction of the full lightness range: an amount of 0.1 takes 10% from the
+// color's perceived lightness, as measured in the CIE L*a*b* color space.
+func Darken(c TerminalColor, amount float64) TerminalColor {
+	return lightenedColor{c: c, amount: -amount}
+}
+
+// Blend returns a color between a and b, where t is 0 for a and 1 for b. Like
+// gradients, colors are blended in the CIE L*u*v* color space.
+func Blend(a, b TerminalColor, t float64) TerminalColor {
+	return blendedColor{a: a, b: b, t: t}
+}
+
+// Complementary returns the color opposite c on the color wheel, with the same
+// saturation and lightness.
+func Complementary(c TerminalColor) TerminalColor {
+	return complementaryColor{c: c}
+}
+
+// Alpha returns the color c would appear as when drawn over bg with the given
+// opacity, from 0 for fully transparent to 1 for fully opaque. Terminals
+// don't support transparency, so this is the next best thing.
+//
+// If bg is NoColor, the terminal's background is assumed to be black or white
+// depending on whether the renderer has a dark background.
+func Alpha(c, bg TerminalColor, alpha float64) TerminalColor {
+	return alphaColor{c: c, bg: bg, alpha: alpha}
+}
+
+// EnsureContrast returns fg, lightened or darkened as little as needed for its
+// contrast ratio against bg to be at least the given ratio. WCAG recommends a
+// ratio of at least 4.5 for normal text and 3 for large text. If the ratio
+// can't be reached at all, the result is black or white, whichever contrasts
+// more with bg.
+//
+// See ContrastRatio for how NoColor is treated.
+func EnsureContrast(fg, bg TerminalColor, ratio float64) TerminalColor {
+	return contrastingColor{fg: fg, bg: bg, ratio: ratio}
+}
+
+// ContrastRatio returns the WCAG contrast ratio between two colors, from 1 for
+// no contrast to 21 for black on white. Colors are compared at full
+// precision, before being degraded to the renderer's color profile.
+//
+// A foreground of NoColor is assumed to be the terminal's default text color,
+// and a background of NoColor the terminal's background: white and black
+// respectively on a dark background, and the reverse on a light one.
+func ContrastRatio(fg, bg TerminalColor) float64 {
+	return renderer.ContrastRatio(fg, bg)
+}
+
+// ContrastRatio returns the WCAG contrast ratio between two colors, resolved
+// with the renderer's background.
+func (r *Renderer) ContrastRatio(fg, bg TerminalColor) float64 {
+	return contrastRatio(r.defaultRGB(fg, false), r.defaultRGB(bg, true))
+}
+
+// lightenedColor is a color with its lightness adjusted. Negative amounts
+// darken it.
+type lightenedColor struct {
+	c      TerminalColor
+	amount float64
+}
+
+func (c lightenedColor) color(r *Renderer) termenv.Color {
+	rgb, ok := resolveRGB(r, c.c)
+	if !ok {
+		return termenv.NoColor{}
+	}
+	l, a, b := rgb.Lab()
+	l = math.Min(1, math.Max(0, l+c.amount))
+	return derivedColor(r, colorful.Lab(l, a, b))
+}
+
+// RGBA returns the RGBA value of this color. This satisfies the Go Color
+// interface.
+//
+// Deprecated.
+func (c lightenedColor) RGBA() (r, g, b, a uint32) {
+	return termenv.ConvertToRGB(c.color(renderer)).RGBA()
+}
+
+// blendedColor is a blend of two colors.
+type blendedColor struct {
+	a, b TerminalColor
+	t    float64
+}
+
+func (c blendedColor) color(r *Renderer) termenv.Color {
+	a, ok := resolveRGB(r, c.a)
+	if !ok {
+		return termenv.NoColor{}
+	}
+	b, ok := resolveRGB(r, c.b)
+	if !ok {
+		return termenv.NoColor{}
+	}
+	return derivedColor(r, a.BlendLuv(b, math.Min(1, math.Max(0, c.t))))
+}
+
+// RGBA returns the RGBA value of this color. This satisfies the Go Color
+// interface.
+//
+// Deprecated.
+func (c blendedColor) RGBA() (r, g, b, a uint32) {
+	return termenv.ConvertToRGB(c.color(renderer)).RGBA()
+}
+
+// complementaryColor is the complement of a color.
+type complementaryColor struct {
+	c TerminalColor
+}
+
+func (c complementaryColor) color(r *Renderer) termenv.Color {
+	rgb, ok := resolveRGB(r, c.c)
+	if !ok {
+		return termenv.NoColor{}
+	}
+	h, s, l := rgb.Hsl()
+	return derivedColor(r, colorful.Hsl(math.Mod(h+180, 360), s, l)) //nolint:gomnd
+}
+
+// RGBA returns the RGBA value of this color. This satisfies the Go Color
+// interface.
+//
+// Deprecated.
+func (c complementaryColor) RGBA() (r, g, b, a uint32) {
+	return termenv.ConvertToRGB(c.color(renderer)).RGBA()
+}
+
+// alphaColor is a translucent color drawn over a background.
+type alphaColor struct {
+	c, bg TerminalColor
+	alpha float64
+}
+
+func (c alphaColor) color(r *Renderer) termenv.Color {
+	fg, ok := resolveRGB(r, c.c)
+	if !ok {
+		return termenv.NoColor{}
+	}
+	bg := r.defaultRGB(c.bg, true)
+	return derivedColor(r, bg.BlendRgb(fg, math.Min(1, math.Max(0, c.alpha))))
+}
+
+// RGBA returns the RGBA value of this color. This satisfies the Go Color
+// interface.
+//
+// Deprecated.
+func (c alphaColor) RGBA() (r, g, b, a uint32) {
+	return termenv.ConvertToRGB(c.color(renderer)).RGBA()
+}
+
+// contrastingColor is a foreground color adjusted to contrast with a
+// background.
+type contrastingColor struct {
+	fg, bg TerminalColor
+	ratio  float64
+}
+
+func (c contrastingColor) color(r *Renderer) termenv.Color {
+	fg := r.defaultRGB(c.fg, false)
+	bg := r.defaultRGB(c.bg, true)
+	if contrastRatio(fg, bg) >= c.ratio {
+		return c.fg.color(r)
+	}
+	return derivedColor(r, ensureContrast(fg, bg, c.ratio))
+}
+
+// RGBA returns the RGBA value of this color. This satisfies the Go Color
+// interface.
+//
+// Deprecated.
+func (c contrastingColor) RGBA() (r, g, b, a uint32) {
+	return termenv.ConvertToRGB(c.color(renderer)).RGBA()
+}
+
+// resolveRGB returns the full precision RGB value of a color, or false if it
+// resolves to no color at all.
+func resolveRGB(r *Renderer, c TerminalColor) (colorful.Color, bool) {
+	if c == nil {
+		return colorful.Color{}, false
+	}
+	tc := c.color(r.trueColor())
+	if _, ok := tc.(termenv.NoColor); ok {
+		return colorful.Color{}, false
+	}
+	return termenv.ConvertToRGB(tc), true
+}
+
+// defaultRGB is like resolveRGB, but stands in the terminal's default text or
+// background color for NoColor.
+func (r *Renderer) defaultRGB(c TerminalColor, isBg bool) colorful.Color {
+	if rgb, ok := resolveRGB(r, c); ok {
+		return rgb
+	}
+	if r.HasDarkBackground() != isBg {
+		return colorful.Color{R: 1, G: 1, B: 1}
+	}
+	return colorful.Color{}
+}
+
+// derivedColor degrades a computed color to the renderer's color profile.
+func derivedColor(r *Renderer, c colorful.Color) termenv.Color {
+	return Color(c.Clamped().Hex()).color(r)
+}
+
+// relativeLuminance returns the WCAG relative luminance of a color.
+func relativeLuminance(c colorful.Color) float64 {
+	r, g, b := c.Clamped().LinearRgb()
+	return 0.2126*r + 0.7152*g + 0.0722*b //nolint:gomnd
+}
+
+// contrastRatio returns the WCAG contrast ratio between two colors.
+func contrastRatio(a, b colorful.Color) float64 {
+	la, lb := relativeLuminance(a), relativeLuminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05) //nolint:gomnd
+}
+
+// ensureContrast adjusts the lightness of fg as little as possible for it to
+// reach the given contrast ratio against bg, trying both lighter and darker
+// variants.
+func ensureContrast(fg, bg colorful.Color, ratio float64) colorful.Color {
+	const steps = 24
+
+	l, a, b := fg.Lab()
+	at := func(l float64) colorful.Color {
+		// Round to what we'll actually end up rendering, so that rounding
+		// can't take us back under the ratio.
+		c, _ := colorful.Hex(colorful.Lab(l, a, b).Clamped().Hex())
+		return c
+	}
+
+	var (
+		best      colorful.Color
+		bestDelta = math.Inf(1)
+	)
+	for _, target := range []float64{0, 1} {
+		if contrastRatio(at(target), bg) < ratio {
+			continue
+		}
+
+		// Find the smallest step towards the target that's enough.
+		lo, hi := 0.0, 1.0
+		for i := 0; i < steps; i++ {
+			mid := (lo + hi) / 2 //nolint:gomnd
+			if contrastRatio(at(l+(target-l)*mid), bg) >= ratio {
+				hi = mid
+			} else {
+				lo = mid
+			}
+		}
+		if delta := math.Abs(target-l) * hi; delta < bestDelta {
+			best, bestDelta = at(l+(target-l)*hi), delta
+		}
+	}
+	if !math.IsInf(bestDelta, 1) {
+		return best
+	}
+
+	black, white := colorful.Color{}, colorful.Color{R: 1, G: 1, B: 1}
+	if contrastRatio(white, bg) > contrastRatio(black, bg) {
+		return white
+	}
+	return black
+}
@@ -0,0 +1,168 @@
+package lipgloss
+
+import "reflect"
+
+// Property is a property set on a style, along with its value.
+type Property struct {
+	// Name is the name of the property, as in style sheets, such as
+	// "padding-left" or "border-top-foreground".
+	Name string
+
+	// Value is the property's value, of the same type as the getter for the
+	// property returns: a bool, int, Position, TerminalColor, Border, Style,
+	// UnderlineStyle, string or func(string) string. For hyperlinks, it's
+	// the URL.
+	Value interface{}
+
+	key  propKey
+	kind propKind
+	raw  interface{}
+}
+
+// String returns the property as a style sheet declaration, like
+// "padding-left: 2".
+func (p Property) String() string {
+	return p.Name + ": " + p.kind.text(p.raw)
+}
+
+// SetProperties returns the properties set on the style, in a fixed order.
+// Properties that have been unset aren't included.
+func (s Style) SetProperties() []Property {
+	var props []Property
+	for _, p := range styleProperties {
+		if s.isSet(p.key) {
+			props = append(props, newProperty(p, s.get(p.key)))
+		}
+	}
+	return props
+}
+
+func newProperty(p styleProperty, v interface{}) Property {
+	prop := Property{Name: p.name, Value: v, key: p.key, kind: p.kind, raw: v}
+	if link, ok := v.(hyperlink); ok {
+		prop.Value = link.url
+	}
+	return prop
+}
+
+// Equal reports whether two styles have the same properties set to the same
+// values, and the same underlying string. Their renderers aren't compared.
+//
+// Transforms are considered equal if they're the same function.
+func (s Style) Equal(other Style) bool {
+	if s.value != other.value || s.props != other.props {
+		return false
+	}
+	for _, p := range styleProperties {
+		if s.isSet(p.key) && !propValuesEqual(s.get(p.key), other.get(p.key)) {
+			return false
+		}
+	}
+	return true
+}
+
+// PropertyChange is the kind of change made to a property.
+type PropertyChange int
+
+// Kinds of property changes.
+const (
+	PropertyAdded PropertyChange = iota
+	PropertyRemoved
+	PropertyChanged
+)
+
+// String returns the name of the change.
+func (c PropertyChange) String() string {
+	switch c {
+	case PropertyAdded:
+		return "added"
+	case PropertyRemoved:
+		return "removed"
+	case PropertyChanged:
+		return "changed"
+	default:
+		return "unknown"
+	}
+}
+
+// PropertyDiff describes how a property differs between two styles.
+type PropertyDiff struct {
+	Change PropertyChange
+
+	// Old is the property in the first style. It's empty if the property
+	// was added.
+	Old Property
+
+	// New is the property in the second style. It's empty if the property
+	// was removed.
+	New Property
+}
+
+// Name returns the name of the property that changed.
+func (d PropertyDiff) Name() string {
+	if d.Change == PropertyAdded {
+		return d.New.Name
+	}
+	return d.Old.Name
+}
+
+// String describes the change in the style of a unified diff:
+//
+//	~ bold: false -> true
+//	+ foreground: #00ff00
+//	- padding-left: 2
+func (d PropertyDiff) String() string {
+	switch d.Change {
+	case PropertyAdded:
+		return "+ " + d.New.String()
+	case PropertyRemoved:
+		return "- " + d.Old.String()
+	default:
+		return "~ " + d.Old.String() + " -> " + d.New.kind.text(d.New.raw)
+	}
+}
+
+// Diff returns how the properties of the other style differ from those of
+// this one: which were added, removed or changed. Differences are listed in
+// the same order as SetProperties. Underlying strings aren't compared.
+func (s Style) Diff(other Style) []PropertyDiff {
+	var diffs []PropertyDiff
+	for _, p := range styleProperties {
+		inS, inOther := s.isSet(p.key), other.isSet(p.key)
+		v, ov := s.get(p.key), other.get(p.key)
+
+		switch {
+		case inS && !inOther:
+			diffs = append(diffs, PropertyDiff{Change: PropertyRemoved, Old: newProperty(p, v)})
+		case !inS && inOther:
+			diffs = append(diffs, PropertyDiff{Change: PropertyAdded, New: newProperty(p, ov)})
+		case inS && inOther && !propValuesEqual(v, ov):
+			diffs = append(diffs, PropertyDiff{
+				Change: PropertyChanged,
+				Old:    newProperty(p, v),
+				New:    newProperty(p, ov),
+			})
+		}
+	}
+
+	return diffs
+}
+
+// propValuesEqual reports whether two property values are equal. Values
+// can't be compared with == as some, like gradients and transforms, aren't
+// comparable.
+func propValuesEqual(a, b interface{}) bool {
+	switch a := a.(type) {
+	case Style:
+		b, ok := b.(Style)
+		return ok && a.Equal(b)
+	case func(string) string:
+		b, ok := b.(func(string) string)
+		if !ok || (a == nil) != (b == nil) {
+			return false
+		}
+		return a == nil || reflect.ValueOf(a).Pointer() == reflect.ValueOf(b).Pointer()
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
@@ -0,0 +1,105 @@
+package lipgloss
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetProperties(t *testing.T) {
+	s := NewStyle().
+		Hyperlink("https://charm.sh").
+		PaddingLeft(2).
+		Foreground(Color("#ff0000")).
+		Bold(true).
+		UnsetPaddingLeft()
+
+	props := s.SetProperties()
+	if len(props) != 3 {
+		t.Fatalf("expected 3 properties, got %d: %v", len(props), props)
+	}
+
+	expected := []struct {
+		name  string
+		value interface{}
+		text  string
+	}{
+		{"bold", true, "bold: true"},
+		{"foreground", Color("#ff0000"), "foreground: #ff0000"},
+		{"hyperlink", "https://charm.sh", `hyperlink: "https://charm.sh"`},
+	}
+	for i, tc := range expected {
+		p := props[i]
+		if p.Name != tc.name || p.Value != tc.value || p.String() != tc.text {
+			t.Errorf("Test %d, expected %s (%v) %q, got %s (%v) %q",
+				i, tc.name, tc.value, tc.text, p.Name, p.Value, p.String())
+		}
+	}
+}
+
+func TestStyleEqual(t *testing.T) {
+	upper := strings.ToUpper
+	base := NewStyle().
+		Bold(true).
+		Foreground(HorizontalGradient(Color("1"), Color("2"))).
+		Transform(upper)
+
+	tt := []struct {
+		a, b     Style
+		expected bool
+	}{
+		{NewStyle(), NewStyle(), true},
+		{base, base.Copy(), true},
+		{base, base.Copy().Bold(false), false},
+		{base, base.Copy().UnsetBold(), false},
+		{base, base.Copy().Foreground(HorizontalGradient(Color("1"), Color("3"))), false},
+		{base, base.Copy().Transform(strings.ToLower), false},
+		{base, base.Copy().SetString("hi"), false},
+		{NewStyle().Bold(true), NewStyle().Bold(true).Underline(false).UnsetUnderline(), true},
+	}
+
+	for i, tc := range tt {
+		if res := tc.a.Equal(tc.b); res != tc.expected {
+			t.Errorf("Test %d, expected %v, got %v", i, tc.expected, res)
+		}
+		if res := tc.b.Equal(tc.a); res != tc.expected {
+			t.Errorf("Test %d (reversed), expected %v, got %v", i, tc.expected, res)
+		}
+	}
+}
+
+func TestStyleDiff(t *testing.T) {
+	a := NewStyle().
+		Bold(true).
+		PaddingLeft(2).
+		Foreground(Color("#ff0000"))
+	b := NewStyle().
+		Italic(true).
+		PaddingLeft(2).
+		Foreground(Color("#00ff00"))
+
+	diffs := a.Diff(b)
+	expected := []string{
+		"- bold: true",
+		"+ italic: true",
+		"~ foreground: #ff0000 -> #00ff00",
+	}
+	if len(diffs) != len(expected) {
+		t.Fatalf("expected %d differences, got %v", len(expected), diffs)
+	}
+	for i, d := range diffs {
+		if d.String() != expected[i] {
+			t.Errorf("Test %d, expected %q, got %q", i, expected[i], d.String())
+		}
+	}
+
+	if diffs[0].Change != PropertyRemoved || diffs[0].Name() != "bold" {
+		t.Errorf("expected bold to be removed, got %s %s", diffs[0].Change, diffs[0].Name())
+	}
+	if diffs[1].Change != PropertyAdded || diffs[1].Name() != "italic" {
+		t.Errorf("expected italic to be added, got %s %s", diffs[1].Change, diffs[1].Name())
+	}
+
+	if d := a.Diff(a.Copy()); len(d) != 0 {
+		t.Errorf("expected no differences, got %v", d)
+	}
+}
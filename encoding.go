@@ -0,0 +1,781 @@
+package lipgloss
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Styles, colors, borders and positions can be encoded as text and JSON, so
+// that they can be stored in configuration files, sent over the wire or
+// snapshot in tests. The text forms are the same as in style sheets: a style
+// is encoded as its property declarations,
+//
+//	bold: true; foreground: #7d56f4; padding-left: 2
+//
+// and a color as a color value, such as #7d56f4, adaptive(#000, #fff) or
+// theme(primary). See StyleSheet for the syntax.
+//
+// Encoding a style records exactly which properties are set, so decoding it
+// results in an identical style. The only exception is Transform, as
+// functions can't be encoded; transforms are left out.
+
+// propKind is the kind of value a style property holds.
+type propKind int
+
+const (
+	boolProp propKind = iota
+	intProp
+	positionProp
+	stringProp
+	colorProp
+	borderProp
+	styleProp
+	underlineStyleProp
+	hyperlinkProp
+	transformProp
+)
+
+// styleProperty is a single property of a style, as it's encoded.
+type styleProperty struct {
+	key  propKey
+	name string
+	kind propKind
+}
+
+// styleProperties lists the properties of a style, in the order they're
+// encoded in.
+var styleProperties = []styleProperty{
+	{boldKey, "bold", boolProp},
+	{italicKey, "italic", boolProp},
+	{underlineKey, "underline", boolProp},
+	{strikethroughKey, "strikethrough", boolProp},
+	{reverseKey, "reverse", boolProp},
+	{blinkKey, "blink", boolProp},
+	{faintKey, "faint", boolProp},
+	{foregroundKey, "foreground", colorProp},
+	{backgroundKey, "background", colorProp},
+	{widthKey, "width", intProp},
+	{heightKey, "height", intProp},
+	{alignHorizontalKey, "align-horizontal", positionProp},
+	{alignVerticalKey, "align-vertical", positionProp},
+	{paddingTopKey, "padding-top", intProp},
+	{paddingRightKey, "padding-right", intProp},
+	{paddingBottomKey, "padding-bottom", intProp},
+	{paddingLeftKey, "padding-left", intProp},
+	{colorWhitespaceKey, "color-whitespace", boolProp},
+	{marginTopKey, "margin-top", intProp},
+	{marginRightKey, "margin-right", intProp},
+	{marginBottomKey, "margin-bottom", intProp},
+	{marginLeftKey, "margin-left", intProp},
+	{marginBackgroundKey, "margin-background", colorProp},
+	{borderStyleKey, "border-style", borderProp},
+	{borderTopKey, "border-top", boolProp},
+	{borderRightKey, "border-right", boolProp},
+	{borderBottomKey, "border-bottom", boolProp},
+	{borderLeftKey, "border-left", boolProp},
+	{borderTopForegroundKey, "border-top-foreground", colorProp},
+	{borderRightForegroundKey, "border-right-foreground", colorProp},
+	{borderBottomForegroundKey, "border-bottom-foreground", colorProp},
+	{borderLeftForegroundKey, "border-left-foreground", colorProp},
+	{borderTopBackgroundKey, "border-top-background", colorProp},
+	{borderRightBackgroundKey, "border-right-background", colorProp},
+	{borderBottomBackgroundKey, "border-bottom-background", colorProp},
+	{borderLeftBackgroundKey, "border-left-background", colorProp},
+	{borderTitleKey, "border-title", stringProp},
+	{borderTitleAlignKey, "border-title-align", positionProp},
+	{borderTitleStyleKey, "border-title-style", styleProp},
+	{borderFooterKey, "border-footer", stringProp},
+	{borderFooterAlignKey, "border-footer-align", positionProp},
+	{borderFooterStyleKey, "border-footer-style", styleProp},
+	{inlineKey, "inline", boolProp},
+	{maxWidthKey, "max-width", intProp},
+	{maxHeightKey, "max-height", intProp},
+	{tabWidthKey, "tab-width", intProp},
+	{underlineSpacesKey, "underline-spaces", boolProp},
+	{strikethroughSpacesKey, "strikethrough-spaces", boolProp},
+	{transformKey, "transform", transformProp},
+	{hyperlinkKey, "hyperlink", hyperlinkProp},
+	{underlineStyleKey, "underline-style", underlineStyleProp},
+	{underlineColorKey, "underline-color", colorProp},
+	{justifyKey, "justify", boolProp},
+	{hyphenateKey, "hyphenate", boolProp},
+	{ellipsisKey, "ellipsis", stringProp},
+	{ellipsisPositionKey, "ellipsis-position", positionProp},
+	{maxHeightIndicatorKey, "max-height-indicator", stringProp},
+}
+
+// stylePropNames maps property names to properties.
+var stylePropNames = func() map[string]styleProperty {
+	m := make(map[string]styleProperty, len(styleProperties))
+	for _, p := range styleProperties {
+		m[p.name] = p
+	}
+	return m
+}()
+
+// MarshalText encodes the style's properties as declarations, as in a style
+// sheet.
+func (s Style) MarshalText() ([]byte, error) {
+	var decls []string
+	for _, p := range styleProperties {
+		if s.isSet(p.key) && p.kind != transformProp {
+			decls = append(decls, p.name+": "+p.kind.text(s.get(p.key)))
+		}
+	}
+	return []byte(strings.Join(decls, "; ")), nil
+}
+
+// UnmarshalText decodes a style from property declarations, as in a style
+// sheet, replacing the style's properties. Errors are of type
+// *StyleSheetError.
+func (s *Style) UnmarshalText(text []byte) error {
+	st, err := parseStyleText(s.r, string(text))
+	if err != nil {
+		return err
+	}
+	s.replaceProps(st)
+	return nil
+}
+
+// replaceProps replaces the style's properties with those of another style,
+// keeping its renderer and underlying string.
+func (s *Style) replaceProps(st Style) {
+	st.r, st.value = s.r, s.value
+	*s = st
+}
+
+// parseStyleText parses property declarations into a new style.
+func parseStyleText(r *Renderer, text string) (Style, error) {
+	st := Style{r: r}
+
+	p := sheetParser{scanner: sheetScanner{src: []rune(text), line: 1, col: 1}}
+	decls, err := p.parseDecls(false)
+	if err != nil {
+		return st, err
+	}
+	for _, decl := range decls {
+		if st, err = applySheetDecl(r, st, decl, true); err != nil {
+			return st, err
+		}
+	}
+	return st, nil
+}
+
+// MarshalJSON encodes the style's properties as a JSON object, with the same
+// property names as in style sheets.
+func (s Style) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for _, p := range styleProperties {
+		if !s.isSet(p.key) || p.kind == transformProp {
+			continue
+		}
+		data, err := p.kind.marshalJSON(s.get(p.key))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p.name, err)
+		}
+		if b.Len() > 1 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.Quote(p.name))
+		b.WriteByte(':')
+		b.Write(data)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a style from a JSON object, replacing the style's
+// properties.
+func (s *Style) UnmarshalJSON(data []byte) error {
+	var props map[string]json.RawMessage
+	if err := json.Unmarshal(data, &props); err != nil {
+		return err
+	}
+
+	st := Style{r: s.r}
+	for name, data := range props {
+		p, ok := stylePropNames[name]
+		if !ok {
+			return fmt.Errorf("unknown property %q", name)
+		}
+		v, err := p.kind.unmarshalJSON(s.r, data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		st.set(p.key, v)
+	}
+
+	s.replaceProps(st)
+	return nil
+}
+
+// text encodes a property value as in a style sheet.
+func (k propKind) text(v interface{}) string {
+	switch k {
+	case boolProp:
+		return strconv.FormatBool(v.(bool))
+	case intProp:
+		return strconv.Itoa(v.(int))
+	case positionProp:
+		return formatFloat(float64(v.(Position)))
+	case stringProp:
+		return quoteSheetString(v.(string))
+	case colorProp:
+		return colorText(v.(TerminalColor))
+	case borderProp:
+		return borderText(v.(Border))
+	case styleProp:
+		text, _ := v.(Style).MarshalText()
+		return quoteSheetString(string(text))
+	case underlineStyleProp:
+		return v.(UnderlineStyle).String()
+	case hyperlinkProp:
+		link := v.(hyperlink)
+		if link.params == "" {
+			return quoteSheetString(link.url)
+		}
+		return quoteSheetString(link.url) + " " + quoteSheetString(link.params)
+	default:
+		// Functions can't be encoded, so this is only for show.
+		return "func"
+	}
+}
+
+// parseText decodes a property value from its style sheet values.
+func (k propKind) parseText(r *Renderer, values []sheetValue) (interface{}, error) {
+	max := 1
+	if k == hyperlinkProp {
+		max = 2
+	}
+	if err := checkValues(values, 1, max); err != nil {
+		return nil, err
+	}
+	v := values[0]
+
+	switch k {
+	case boolProp:
+		return parseSheetBool(v)
+	case intProp:
+		return parseSheetInt(v)
+	case positionProp:
+		return parseSheetPosition(v)
+	case stringProp:
+		return parseSheetText(v)
+	case colorProp:
+		return parseSheetColor(v)
+	case borderProp:
+		return parseSheetBorder(v)
+	case styleProp:
+		text, err := parseSheetText(v)
+		if err != nil {
+			return nil, err
+		}
+		st, err := parseStyleText(r, text)
+		if err != nil {
+			// Report the error relative to the string it's in.
+			return nil, v.errorf("in style: %s", err)
+		}
+		return st, nil
+	case underlineStyleProp:
+		return parseSheetUnderlineStyle(v)
+	case hyperlinkProp:
+		var link hyperlink
+		var err error
+		if link.url, err = parseSheetText(v); err != nil {
+			return nil, err
+		}
+		if len(values) > 1 {
+			link.params, err = parseSheetText(values[1])
+		}
+		return link, err
+	default:
+		return nil, v.errorf("functions can't be decoded")
+	}
+}
+
+// hyperlinkJSON is the JSON encoding of a hyperlink.
+type hyperlinkJSON struct {
+	URL    string `json:"url"`
+	Params string `json:"params,omitempty"`
+}
+
+// marshalJSON encodes a property value as JSON.
+func (k propKind) marshalJSON(v interface{}) ([]byte, error) {
+	switch k {
+	case colorProp:
+		return json.Marshal(colorText(v.(TerminalColor)))
+	case hyperlinkProp:
+		link := v.(hyperlink)
+		return json.Marshal(hyperlinkJSON{URL: link.url, Params: link.params})
+	default:
+		return json.Marshal(v)
+	}
+}
+
+// unmarshalJSON decodes a property value from JSON.
+func (k propKind) unmarshalJSON(r *Renderer, data []byte) (interface{}, error) {
+	var err error
+	switch k {
+	case boolProp:
+		var v bool
+		err = json.Unmarshal(data, &v)
+		return v, err
+	case intProp:
+		var v int
+		err = json.Unmarshal(data, &v)
+		return v, err
+	case positionProp:
+		var v Position
+		err = json.Unmarshal(data, &v)
+		return v, err
+	case stringProp:
+		var v string
+		err = json.Unmarshal(data, &v)
+		return v, err
+	case colorProp:
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return nil, err
+		}
+		return parseColorText(text)
+	case borderProp:
+		var v Border
+		err = json.Unmarshal(data, &v)
+		return v, err
+	case styleProp:
+		v := Style{r: r}
+		err = json.Unmarshal(data, &v)
+		return v, err
+	case underlineStyleProp:
+		var v UnderlineStyle
+		err = json.Unmarshal(data, &v)
+		return v, err
+	case hyperlinkProp:
+		var v hyperlinkJSON
+		err = json.Unmarshal(data, &v)
+		return hyperlink{url: v.URL, params: v.Params}, err
+	default:
+		return nil, fmt.Errorf("functions can't be decoded")
+	}
+}
+
+// parseSheetValueText parses a single style sheet value from text.
+func parseSheetValueText(text string) (sheetValue, error) {
+	p := sheetParser{scanner: sheetScanner{src: []rune(text), line: 1, col: 1}}
+	v, err := p.parseValue()
+	if err != nil {
+		return v, err
+	}
+	if t, err := p.next(); err != nil || t.kind != sheetEOF {
+		if err == nil {
+			err = t.errorf("unexpected %s", t)
+		}
+		return v, err
+	}
+	return v, nil
+}
+
+// parseColorText parses a color from its text form.
+func parseColorText(text string) (TerminalColor, error) {
+	v, err := parseSheetValueText(text)
+	if err != nil {
+		return noColor, err
+	}
+	return parseSheetColor(v)
+}
+
+// unmarshalColorText parses a color of a particular type from its text form.
+func unmarshalColorText(text []byte, c interface{}) error {
+	v, err := parseColorText(string(text))
+	if err != nil {
+		return err
+	}
+
+	switch c := c.(type) {
+	case *Color:
+		if v, ok := v.(Color); ok {
+			*c = v
+			return nil
+		}
+	case *ANSIColor:
+		if v, ok := v.(ANSIColor); ok {
+			*c = v
+			return nil
+		}
+	case *AdaptiveColor:
+		if v, ok := v.(AdaptiveColor); ok {
+			*c = v
+			return nil
+		}
+	case *CompleteColor:
+		if v, ok := v.(CompleteColor); ok {
+			*c = v
+			return nil
+		}
+	case *CompleteAdaptiveColor:
+		if v, ok := v.(CompleteAdaptiveColor); ok {
+			*c = v
+			return nil
+		}
+	case *ThemeColor:
+		if v, ok := v.(ThemeColor); ok {
+			*c = v
+			return nil
+		}
+	case *Gradient:
+		if v, ok := v.(Gradient); ok {
+			*c = v
+			return nil
+		}
+	case *NoColor:
+		if v, ok := v.(NoColor); ok {
+			*c = v
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%q is not a %T", text, c)
+}
+
+// unmarshalColorJSON decodes a color from a JSON string holding its text form,
+// or as colors were encoded before they had a text form: an object with its
+// fields, or a number for ANSI colors. fields is the color converted to a
+// type without methods.
+func unmarshalColorJSON(data []byte, c encoding.TextUnmarshaler, fields interface{}) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		return c.UnmarshalText([]byte(text))
+	}
+	return json.Unmarshal(data, fields)
+}
+
+// colorText encodes a color as in a style sheet.
+func colorText(c TerminalColor) string {
+	colors := func(cs ...TerminalColor) string {
+		texts := make([]string, len(cs))
+		for i, c := range cs {
+			texts[i] = colorText(c)
+		}
+		return strings.Join(texts, ", ")
+	}
+
+	switch c := c.(type) {
+	case Color:
+		return colorWord(string(c))
+	case ANSIColor:
+		return "ansi(" + strconv.FormatUint(uint64(c), 10) + ")"
+	case AdaptiveColor:
+		return "adaptive(" + colorWord(c.Light) + ", " + colorWord(c.Dark) + ")"
+	case CompleteColor:
+		return "complete(" + colorWord(c.TrueColor) + ", " + colorWord(c.ANSI256) + ", " + colorWord(c.ANSI) + ")"
+	case CompleteAdaptiveColor:
+		return "adaptive(" + colors(c.Light, c.Dark) + ")"
+	case ThemeColor:
+		return "theme(" + nameText(string(c)) + ")"
+	case Gradient:
+		if c.Vertical {
+			return "vertical-gradient(" + colors(c.Colors...) + ")"
+		}
+		return "gradient(" + colors(c.Colors...) + ")"
+	case lightenedColor:
+		if c.amount < 0 {
+			return "darken(" + colorText(c.c) + ", " + formatFloat(-c.amount) + ")"
+		}
+		return "lighten(" + colorText(c.c) + ", " + formatFloat(c.amount) + ")"
+	case blendedColor:
+		return "blend(" + colors(c.a, c.b) + ", " + formatFloat(c.t) + ")"
+	case complementaryColor:
+		return "complementary(" + colorText(c.c) + ")"
+	case alphaColor:
+		return "alpha(" + colors(c.c, c.bg) + ", " + formatFloat(c.alpha) + ")"
+	case contrastingColor:
+		return "ensure-contrast(" + colors(c.fg, c.bg) + ", " + formatFloat(c.ratio) + ")"
+	default:
+		return "none"
+	}
+}
+
+// colorWord encodes a plain color, quoting it unless it's a hex color or an
+// ANSI color number.
+func colorWord(s string) string {
+	if isColorWord(s) {
+		return s
+	}
+	return quoteSheetString(s)
+}
+
+// nameText encodes a name, quoting it if it can't be written as a word.
+func nameText(s string) string {
+	if s == "" || strings.ContainsAny(s, " \t\r\n{};:,()\"/") {
+		return quoteSheetString(s)
+	}
+	return s
+}
+
+// quoteSheetString quotes a string as in a style sheet.
+func quoteSheetString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// formatFloat formats a number with no more precision than needed.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// borderNames lists the built-in borders by name.
+var borderNames = []struct {
+	name   string
+	border Border
+}{
+	{"none", noBorder},
+	{"normal", normalBorder},
+	{"rounded", roundedBorder},
+	{"block", blockBorder},
+	{"outer-half-block", outerHalfBlockBorder},
+	{"inner-half-block", innerHalfBlockBorder},
+	{"thick", thickBorder},
+	{"double", doubleBorder},
+	{"hidden", hiddenBorder},
+}
+
+// borderParts returns pointers to the parts of a border, in field order.
+func borderParts(b *Border) []*string {
+	return []*string{
+		&b.Top, &b.Bottom, &b.Left, &b.Right,
+		&b.TopLeft, &b.TopRight, &b.BottomLeft, &b.BottomRight,
+		&b.MiddleLeft, &b.MiddleRight, &b.Middle, &b.MiddleTop, &b.MiddleBottom,
+	}
+}
+
+// borderText encodes a border by name, or as a list of its parts if it's not
+// one of the built-in borders.
+func borderText(b Border) string {
+	for _, nb := range borderNames {
+		if nb.border == b {
+			return nb.name
+		}
+	}
+
+	parts := borderParts(&b)
+
+	// Leave out trailing empty parts.
+	n := len(parts)
+	for n > 0 && *parts[n-1] == "" {
+		n--
+	}
+	texts := make([]string, n)
+	for i, p := range parts[:n] {
+		texts[i] = quoteSheetString(*p)
+	}
+	return "border(" + strings.Join(texts, ", ") + ")"
+}
+
+// MarshalText encodes the border by name, such as "rounded", or for custom
+// borders as border("─", "─", "│", "│", ...), with the parts in field order.
+func (b Border) MarshalText() ([]byte, error) {
+	return []byte(borderText(b)), nil
+}
+
+// UnmarshalText decodes a border from its text form.
+func (b *Border) UnmarshalText(text []byte) error {
+	v, err := parseSheetValueText(string(text))
+	if err != nil {
+		return err
+	}
+	*b, err = parseSheetBorder(v)
+	return err
+}
+
+// MarshalJSON encodes the border by name if it's one of the built-in borders,
+// and as an object with its parts otherwise.
+func (b Border) MarshalJSON() ([]byte, error) {
+	for _, nb := range borderNames {
+		if nb.border == b {
+			return json.Marshal(nb.name)
+		}
+	}
+	type border Border
+	return json.Marshal(border(b))
+}
+
+// UnmarshalJSON decodes a border from either a name or an object with its
+// parts.
+func (b *Border) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		return b.UnmarshalText([]byte(name))
+	}
+	type border Border
+	return json.Unmarshal(data, (*border)(b))
+}
+
+// MarshalText encodes the position as a number. Positions that aren't finite
+// numbers can't be encoded.
+func (p Position) MarshalText() ([]byte, error) {
+	f := float64(p)
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, fmt.Errorf("position %v is not a finite number", f)
+	}
+	return []byte(formatFloat(f)), nil
+}
+
+// UnmarshalText decodes a position from a number, or one of left, center,
+// right, top or bottom.
+func (p *Position) UnmarshalText(text []byte) error {
+	v, err := parseSheetValueText(string(text))
+	if err != nil {
+		return err
+	}
+	*p, err = parseSheetPosition(v)
+	return err
+}
+
+// MarshalJSON encodes the position as a number.
+func (p Position) MarshalJSON() ([]byte, error) {
+	return p.MarshalText()
+}
+
+// UnmarshalJSON decodes a position from a number, or a string holding one of
+// the position names.
+func (p *Position) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		return p.UnmarshalText([]byte(name))
+	}
+	var f float64
+	if err := json.Unmarshal(data, &f); err != nil {
+		return err
+	}
+	*p = Position(f)
+	return nil
+}
+
+// MarshalText encodes the color, as in a style sheet.
+func (c Color) MarshalText() ([]byte, error) {
+	return []byte(colorText(c)), nil
+}
+
+// UnmarshalText decodes the color, as in a style sheet.
+func (c *Color) UnmarshalText(text []byte) error {
+	return unmarshalColorText(text, c)
+}
+
+// MarshalText encodes the color, as in a style sheet.
+func (ac ANSIColor) MarshalText() ([]byte, error) {
+	return []byte(colorText(ac)), nil
+}
+
+// UnmarshalText decodes the color, as in a style sheet.
+func (ac *ANSIColor) UnmarshalText(text []byte) error {
+	return unmarshalColorText(text, ac)
+}
+
+// UnmarshalJSON decodes the color from a string, as in a style sheet, or from
+// a number.
+func (ac *ANSIColor) UnmarshalJSON(data []byte) error {
+	type ansiColor ANSIColor
+	return unmarshalColorJSON(data, ac, (*ansiColor)(ac))
+}
+
+// MarshalText encodes the color, as in a style sheet.
+func (ac AdaptiveColor) MarshalText() ([]byte, error) {
+	return []byte(colorText(ac)), nil
+}
+
+// UnmarshalText decodes the color, as in a style sheet.
+func (ac *AdaptiveColor) UnmarshalText(text []byte) error {
+	return unmarshalColorText(text, ac)
+}
+
+// UnmarshalJSON decodes the color from a string, as in a style sheet, or from
+// an object with its fields.
+func (ac *AdaptiveColor) UnmarshalJSON(data []byte) error {
+	type adaptiveColor AdaptiveColor
+	return unmarshalColorJSON(data, ac, (*adaptiveColor)(ac))
+}
+
+// MarshalText encodes the color, as in a style sheet.
+func (c CompleteColor) MarshalText() ([]byte, error) {
+	return []byte(colorText(c)), nil
+}
+
+// UnmarshalText decodes the color, as in a style sheet.
+func (c *CompleteColor) UnmarshalText(text []byte) error {
+	return unmarshalColorText(text, c)
+}
+
+// UnmarshalJSON decodes the color from a string, as in a style sheet, or from
+// an object with its fields.
+func (c *CompleteColor) UnmarshalJSON(data []byte) error {
+	type completeColor CompleteColor
+	return unmarshalColorJSON(data, c, (*completeColor)(c))
+}
+
+// MarshalText encodes the color, as in a style sheet.
+func (cac CompleteAdaptiveColor) MarshalText() ([]byte, error) {
+	return []byte(colorText(cac)), nil
+}
+
+// UnmarshalText decodes the color, as in a style sheet.
+func (cac *CompleteAdaptiveColor) UnmarshalText(text []byte) error {
+	return unmarshalColorText(text, cac)
+}
+
+// UnmarshalJSON decodes the color from a string, as in a style sheet, or from
+// an object with its fields.
+func (cac *CompleteAdaptiveColor) UnmarshalJSON(data []byte) error {
+	type completeAdaptiveColor CompleteAdaptiveColor
+	return unmarshalColorJSON(data, cac, (*completeAdaptiveColor)(cac))
+}
+
+// MarshalText encodes the color, as in a style sheet.
+func (c ThemeColor) MarshalText() ([]byte, error) {
+	return []byte(colorText(c)), nil
+}
+
+// UnmarshalText decodes the color, as in a style sheet.
+func (c *ThemeColor) UnmarshalText(text []byte) error {
+	return unmarshalColorText(text, c)
+}
+
+// MarshalText encodes the gradient, as in a style sheet.
+func (g Gradient) MarshalText() ([]byte, error) {
+	return []byte(colorText(g)), nil
+}
+
+// UnmarshalText decodes the gradient, as in a style sheet.
+func (g *Gradient) UnmarshalText(text []byte) error {
+	return unmarshalColorText(text, g)
+}
+
+// MarshalText encodes the absence of color as none.
+func (n NoColor) MarshalText() ([]byte, error) {
+	return []byte(colorText(n)), nil
+}
+
+// UnmarshalText decodes the absence of color from none.
+func (n *NoColor) UnmarshalText(text []byte) error {
+	return unmarshalColorText(text, n)
+}
@@ -0,0 +1,309 @@
+package lipgloss
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestStyleText(t *testing.T) {
+	s := NewStyle().
+		Bold(true).
+		Italic(false).
+		Foreground(Color("#7d56f4")).
+		Background(AdaptiveColor{Light: "255", Dark: "#1c1c1c"}).
+		Padding(0, 2).
+		Border(RoundedBorder(), true, false).
+		BorderTitle("Say \"hi\"\n").
+		Hyperlink("https://charm.sh", "id=1")
+
+	text, err := s.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `bold: true; italic: false; foreground: #7d56f4; ` +
+		`background: adaptive(255, #1c1c1c); padding-top: 0; padding-right: 2; ` +
+		`padding-bottom: 0; padding-left: 2; border-style: rounded; ` +
+		`border-top: true; border-right: false; border-bottom: true; border-left: false; ` +
+		`border-title: "Say \"hi\"\n"; hyperlink: "https://charm.sh" "id=1"`
+	if string(text) != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, text)
+	}
+
+	var res Style
+	if err := res.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+	if !res.Equal(s) {
+		t.Errorf("Expected %v, got %v", s.SetProperties(), res.SetProperties())
+	}
+}
+
+func TestStyleRoundTrip(t *testing.T) {
+	custom := Border{Top: "=", Bottom: "=", Left: "|", Right: "|", TopLeft: "+"}
+
+	tt := []struct {
+		name  string
+		style Style
+	}{
+		{"empty", NewStyle()},
+		{"unset", NewStyle().Bold(true).UnsetBold()},
+		{"underline", NewStyle().UnderlineStyle(UnderlineCurly).Underline(false).UnderlineColor(ANSIColor(9))},
+		{"borders", NewStyle().BorderStyle(custom).BorderTopForeground(Color("1")).BorderFooterStyle(NewStyle().Italic(true).Foreground(Color("2")))},
+		{"sizes", NewStyle().Width(10).Height(2).MaxWidth(8).MaxHeight(1).TabWidth(NoTabConversion)},
+		{"positions", NewStyle().Align(Center, Bottom).EllipsisPosition(0.25)},
+		{"text", NewStyle().Ellipsis("…").MaxHeightIndicator("(%d more)")},
+		{"colors", NewStyle().
+			Foreground(CompleteAdaptiveColor{
+				Light: CompleteColor{TrueColor: "#ffffff", ANSI256: "231", ANSI: "15"},
+				Dark:  CompleteColor{TrueColor: "#000000", ANSI256: "16"},
+			}).
+			Background(VerticalGradient(ThemeColor("primary"), Darken(ThemeColor("my accent"), 0.2))).
+			MarginBackground(EnsureContrast(Blend(Color("1"), Color("2"), 0.5), NoColor{}, 4.5)).
+			UnderlineColor(Alpha(Complementary(Color("red")), Lighten(Color("#000"), 0.1), 0.5)),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			text, err := tc.style.MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fromText Style
+			if err := fromText.UnmarshalText(text); err != nil {
+				t.Fatalf("%s: %v", text, err)
+			}
+			if !fromText.Equal(tc.style) {
+				t.Errorf("Text round trip failed:\n%s\n%v", text, tc.style.Diff(fromText))
+			}
+
+			data, err := json.Marshal(tc.style)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fromJSON Style
+			if err := json.Unmarshal(data, &fromJSON); err != nil {
+				t.Fatalf("%s: %v", data, err)
+			}
+			if !fromJSON.Equal(tc.style) {
+				t.Errorf("JSON round trip failed:\n%s\n%v", data, tc.style.Diff(fromJSON))
+			}
+		})
+	}
+}
+
+func TestStyleJSON(t *testing.T) {
+	s := NewStyle().
+		Bold(true).
+		Foreground(Color("#7d56f4")).
+		AlignHorizontal(Center).
+		BorderStyle(NormalBorder()).
+		UnderlineStyle(UnderlineDouble).
+		Hyperlink("https://charm.sh")
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"bold":true,"underline":true,"foreground":"#7d56f4","align-horizontal":0.5,` +
+		`"border-style":"normal","hyperlink":{"url":"https://charm.sh"},"underline-style":"double"}`
+	if string(data) != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, data)
+	}
+
+	var res Style
+	err = json.Unmarshal([]byte(`{"bold": true, "align-horizontal": "right", "foreground": "adaptive(1, 2)"}`), &res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.GetBold() || res.GetAlignHorizontal() != Right || res.GetForeground() != (AdaptiveColor{Light: "1", Dark: "2"}) {
+		t.Errorf("Unexpected style %v", res.SetProperties())
+	}
+
+	if err := json.Unmarshal([]byte(`{"colour": "1"}`), &res); err == nil {
+		t.Error("Expected an error for an unknown property")
+	}
+	if err := json.Unmarshal([]byte(`{"foreground": "#12"}`), &res); err == nil {
+		t.Error("Expected an error for an invalid color")
+	}
+}
+
+func TestStyleJSONTabWidth(t *testing.T) {
+	var st Style
+	if err := json.Unmarshal([]byte(`{"tab-width": -5}`), &st); err != nil {
+		t.Fatal(err)
+	}
+	if w := st.GetTabWidth(); w != NoTabConversion {
+		t.Errorf("Expected tab width %d, got %d", NoTabConversion, w)
+	}
+	if res := st.Render("a\tb"); res != "a\tb" {
+		t.Errorf("Expected tabs to be kept, got %q", res)
+	}
+}
+
+func TestColorText(t *testing.T) {
+	tt := []struct {
+		color    TerminalColor
+		expected string
+	}{
+		{Color("#7d56f4"), `"#7d56f4"`},
+		{Color("red"), `"\"red\""`},
+		{ANSIColor(5), `"ansi(5)"`},
+		{NoColor{}, `"none"`},
+		{AdaptiveColor{Light: "#fff", Dark: ""}, `"adaptive(#fff, \"\")"`},
+		{CompleteColor{TrueColor: "#fff", ANSI256: "231", ANSI: "15"}, `"complete(#fff, 231, 15)"`},
+		{ThemeColor("error"), `"theme(error)"`},
+		{HorizontalGradient(Color("1"), Color("2")), `"gradient(1, 2)"`},
+	}
+
+	for _, tc := range tt {
+		data, err := json.Marshal(tc.color)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != tc.expected {
+			t.Errorf("Expected %s, got %s", tc.expected, data)
+		}
+
+		// Decode into a new value of the same type.
+		v := reflect.New(reflect.TypeOf(tc.color))
+		if err := json.Unmarshal(data, v.Interface()); err != nil {
+			t.Fatalf("%s: %v", data, err)
+		}
+		if !reflect.DeepEqual(v.Elem().Interface(), tc.color) {
+			t.Errorf("Expected %#v, got %#v", tc.color, v.Elem().Interface())
+		}
+	}
+
+	var c Color
+	if err := c.UnmarshalText([]byte("ansi(5)")); err == nil {
+		t.Error("Expected an error decoding an ANSI color into a Color")
+	}
+}
+
+func TestColorJSONObject(t *testing.T) {
+	// Colors with fields used to be encoded as objects, and ANSI colors as
+	// numbers.
+	ansi := ANSIColor(5)
+	tt := []struct {
+		input    string
+		color    interface{}
+		expected interface{}
+	}{
+		{`{"Light":"#fff","Dark":"#000"}`, &AdaptiveColor{}, &AdaptiveColor{Light: "#fff", Dark: "#000"}},
+		{`{"TrueColor":"#fff","ANSI256":"231","ANSI":"15"}`, &CompleteColor{}, &CompleteColor{TrueColor: "#fff", ANSI256: "231", ANSI: "15"}},
+		{
+			`{"Light":{"TrueColor":"#fff","ANSI256":"231","ANSI":"15"},"Dark":"complete(#000, 16, 0)"}`,
+			&CompleteAdaptiveColor{},
+			&CompleteAdaptiveColor{
+				Light: CompleteColor{TrueColor: "#fff", ANSI256: "231", ANSI: "15"},
+				Dark:  CompleteColor{TrueColor: "#000", ANSI256: "16", ANSI: "0"},
+			},
+		},
+		{`"adaptive(#fff, #000)"`, &AdaptiveColor{}, &AdaptiveColor{Light: "#fff", Dark: "#000"}},
+		{`5`, new(ANSIColor), &ansi},
+		{`"ansi(5)"`, new(ANSIColor), &ansi},
+	}
+
+	for _, tc := range tt {
+		if err := json.Unmarshal([]byte(tc.input), tc.color); err != nil {
+			t.Fatalf("%s: %v", tc.input, err)
+		}
+		if !reflect.DeepEqual(tc.color, tc.expected) {
+			t.Errorf("Expected %#v from %s, got %#v", tc.expected, tc.input, tc.color)
+		}
+	}
+
+	// ANSI colors used to be encoded as numbers, and now round-trip as text.
+	data, err := json.Marshal(struct{ C ANSIColor }{5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var res struct{ C ANSIColor }
+	if err := json.Unmarshal(data, &res); err != nil || res.C != 5 {
+		t.Errorf("Expected ansi(5) from %s, got %v (%v)", data, res.C, err)
+	}
+
+	var ac AdaptiveColor
+	if err := json.Unmarshal([]byte(`"ansi(5)"`), &ac); err == nil {
+		t.Error("Expected an error decoding an ANSI color into an AdaptiveColor")
+	}
+}
+
+func TestBorderJSON(t *testing.T) {
+	data, err := json.Marshal(RoundedBorder())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `"rounded"` {
+		t.Errorf("Expected \"rounded\", got %s", data)
+	}
+
+	custom := Border{Top: "~", Left: "("}
+	data, err = json.Marshal(custom)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b Border
+	if err := json.Unmarshal(data, &b); err != nil {
+		t.Fatal(err)
+	}
+	if b != custom {
+		t.Errorf("Expected %#v, got %#v", custom, b)
+	}
+
+	text, _ := custom.MarshalText()
+	if string(text) != `border("~", "", "(")` {
+		t.Errorf("Unexpected border text %s", text)
+	}
+	b = Border{}
+	if err := b.UnmarshalText(text); err != nil || b != custom {
+		t.Errorf("Expected %#v, got %#v (%v)", custom, b, err)
+	}
+}
+
+func TestPositionJSON(t *testing.T) {
+	data, err := json.Marshal(struct{ P Position }{0.25})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"P":0.25}` {
+		t.Errorf("Unexpected JSON %s", data)
+	}
+
+	for input, expected := range map[string]Position{`0.75`: 0.75, `"center"`: Center, `"bottom"`: Bottom} {
+		var p Position
+		if err := json.Unmarshal([]byte(input), &p); err != nil || p != expected {
+			t.Errorf("Expected %v from %s, got %v (%v)", expected, input, p, err)
+		}
+	}
+}
+
+func TestPositionRoundTrip(t *testing.T) {
+	for _, p := range []Position{0, 0.25, Center, 1, -0.5, 1.5, 1e21} {
+		text, err := p.MarshalText()
+		if err != nil {
+			t.Fatalf("%v: %v", p, err)
+		}
+		var res Position
+		if err := res.UnmarshalText(text); err != nil || res != p {
+			t.Errorf("Expected %v from %s, got %v (%v)", p, text, res, err)
+		}
+
+		st := NewStyle().AlignHorizontal(p)
+		text, _ = st.MarshalText()
+		var sres Style
+		if err := sres.UnmarshalText(text); err != nil || sres.GetAlignHorizontal() != st.GetAlignHorizontal() {
+			t.Errorf("Expected %v from %s, got %v (%v)", p, text, sres.GetAlignHorizontal(), err)
+		}
+	}
+
+	for _, f := range []float64{math.NaN(), math.Inf(1)} {
+		if _, err := Position(f).MarshalText(); err == nil {
+			t.Errorf("Expected an error encoding %v", f)
+		}
+	}
+}
@@ -0,0 +1,411 @@
+package lipgloss
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+// Dimensions of a single cell in SVG output, in pixels.
+const (
+	svgFontSize   = 14
+	svgCellWidth  = 8.4
+	svgCellHeight = 18
+	svgBaseline   = 14
+	svgPadding    = 10
+)
+
+// HTML converts styled output, as produced by Style.Render and the other
+// lipgloss functions, into an HTML <pre> element with inline styles. Colors,
+// bold, faint, italic, underlines, strikethroughs, reverse video and
+// hyperlinks are carried over; other escape sequences are dropped.
+//
+// Colors are only as good as the profile they were rendered with, so render
+// with a renderer set to termenv.TrueColor for the most faithful result:
+//
+//	r := lipgloss.NewRenderer(io.Discard)
+//	r.SetColorProfile(termenv.TrueColor)
+//	out := r.HTML(r.NewStyle().Bold(true).Render("Hello"))
+func HTML(str string) string {
+	return renderer.HTML(str)
+}
+
+// HTML converts styled output into an HTML <pre> element with inline styles.
+// The default text and background colors are picked based on whether the
+// renderer has a dark background.
+func (r *Renderer) HTML(str string) string {
+	fg, bg := r.exportColors()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<pre style="color:%s;background-color:%s">`, fg, bg)
+	for i, line := range parseRuns(r, str) {
+		if i > 0 {
+			b.WriteRune('\n')
+		}
+		for _, run := range line {
+			text := html.EscapeString(run.text)
+			if css := run.style.css(fg, bg); css != "" {
+				text = `<span style="` + css + `">` + text + `</span>`
+			}
+			if run.style.link != "" {
+				text = `<a href="` + html.EscapeString(run.style.link) + `">` + text + `</a>`
+			}
+			b.WriteString(text)
+		}
+	}
+	b.WriteString("</pre>")
+	return b.String()
+}
+
+// SVG converts styled output, as produced by Style.Render and the other
+// lipgloss functions, into a standalone SVG image. Text is laid out on a
+// monospace grid so that borders and alignment survive regardless of the
+// font the viewer ends up using.
+//
+// See HTML for details on which styles are carried over.
+func SVG(str string) string {
+	return renderer.SVG(str)
+}
+
+// SVG converts styled output into a standalone SVG image. The default text
+// and background colors are picked based on whether the renderer has a dark
+// background.
+func (r *Renderer) SVG(str string) string {
+	fg, bg := r.exportColors()
+	lines := parseRuns(r, str)
+
+	var cols int
+	for _, line := range lines {
+		var w int
+		for _, run := range line {
+			w += run.width
+		}
+		cols = max(cols, w)
+	}
+
+	width := svgNumber(float64(cols)*svgCellWidth + 2*svgPadding)
+	height := svgNumber(float64(len(lines)*svgCellHeight + 2*svgPadding))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s">`,
+		width, height, width, height)
+	b.WriteString("\n")
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="%s"/>`, bg)
+	b.WriteString("\n")
+	fmt.Fprintf(&b, `<g font-family="monospace" font-size="%d" fill="%s" xml:space="preserve">`, svgFontSize, fg)
+	b.WriteString("\n")
+
+	for row, line := range lines {
+		var col int
+		for _, run := range line {
+			x := svgNumber(float64(col)*svgCellWidth + svgPadding)
+			y := row*svgCellHeight + svgPadding
+			w := svgNumber(float64(run.width) * svgCellWidth)
+			col += run.width
+
+			runFg, runBg := run.style.colors(fg, bg)
+			if runBg != bg {
+				fmt.Fprintf(&b, `<rect x="%s" y="%d" width="%s" height="%d" fill="%s"/>`,
+					x, y, w, svgCellHeight, runBg)
+				b.WriteString("\n")
+			}
+			if strings.TrimSpace(run.text) == "" && !run.style.decorated() {
+				continue
+			}
+
+			if run.style.link != "" {
+				fmt.Fprintf(&b, `<a href="%s">`, html.EscapeString(run.style.link))
+			}
+			fmt.Fprintf(&b, `<text x="%s" y="%d" textLength="%s" lengthAdjust="spacingAndGlyphs"`,
+				x, y+svgBaseline, w)
+			if runFg != fg {
+				fmt.Fprintf(&b, ` fill="%s"`, runFg)
+			}
+			if run.style.faint {
+				b.WriteString(` fill-opacity="0.5"`)
+			}
+			if css := run.style.fontCSS(); css != "" {
+				fmt.Fprintf(&b, ` style="%s"`, css)
+			}
+			b.WriteString(">" + html.EscapeString(run.text) + "</text>")
+			if run.style.link != "" {
+				b.WriteString("</a>")
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("</g>\n</svg>")
+	return b.String()
+}
+
+// exportColors returns the default text and background colors for exported
+// output.
+func (r *Renderer) exportColors() (fg, bg string) {
+	if r.HasDarkBackground() {
+		return "#d0d0d0", "#1c1c1c"
+	}
+	return "#1c1c1c", "#ffffff"
+}
+
+// svgNumber formats a coordinate with no more precision than needed.
+func svgNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// run is a stretch of text on a single line sharing the same style.
+type run struct {
+	text  string
+	width int
+	style exportStyle
+}
+
+// parseRuns breaks styled output into lines of runs, decoding SGR sequences
+// and hyperlinks along the way.
+func parseRuns(r *Renderer, str string) [][]run {
+	var (
+		lines [][]run
+		st    exportStyle
+	)
+
+	for _, l := range strings.Split(str, "\n") {
+		var (
+			line []run
+			seg  = newSegmenter(r, l)
+		)
+		for seg.next() {
+			if seg.esc {
+				switch {
+				case isSGR(seg.text):
+					st.apply(seg.text)
+				case isHyperlink(seg.text):
+					st.link = hyperlinkURL(seg.text)
+				}
+				continue
+			}
+
+			if len(line) > 0 && line[len(line)-1].style == st {
+				line[len(line)-1].text += seg.text
+				line[len(line)-1].width += seg.width
+				continue
+			}
+			line = append(line, run{text: seg.text, width: seg.width, style: st})
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// exportStyle is the styling state decoded from a series of SGR sequences.
+// Colors are hex strings, with the empty string meaning the default color.
+type exportStyle struct {
+	fg, bg         string
+	underlineColor string
+	underline      UnderlineStyle
+	bold           bool
+	faint          bool
+	italic         bool
+	reverse        bool
+	strikethrough  bool
+	link           string
+}
+
+// apply updates the style with the parameters of an SGR sequence.
+func (st *exportStyle) apply(seq string) {
+	params := strings.Split(strings.TrimSuffix(strings.TrimPrefix(seq, "\x1b["), "m"), ";")
+
+	for i := 0; i < len(params); i++ {
+		p := params[i]
+
+		// Extended underlines use a colon-separated sub-parameter.
+		if strings.HasPrefix(p, "4:") {
+			n, _ := strconv.Atoi(p[2:])
+			if n < int(UnderlineNone) || n > int(UnderlineDashed) {
+				n = int(UnderlineSingle)
+			}
+			st.underline = UnderlineStyle(n)
+			continue
+		}
+
+		n, err := strconv.Atoi(p)
+		if err != nil && p != "" {
+			continue
+		}
+
+		switch {
+		case n >= 30 && n <= 37:
+			st.fg = termenv.ANSIColor(n - 30).String()
+			continue
+		case n >= 90 && n <= 97:
+			st.fg = termenv.ANSIColor(n - 90 + 8).String()
+			continue
+		case n >= 40 && n <= 47:
+			st.bg = termenv.ANSIColor(n - 40).String()
+			continue
+		case n >= 100 && n <= 107:
+			st.bg = termenv.ANSIColor(n - 100 + 8).String()
+			continue
+		}
+
+		switch n {
+		case 0:
+			*st = exportStyle{link: st.link}
+		case 1:
+			st.bold = true
+		case 2:
+			st.faint = true
+		case 3:
+			st.italic = true
+		case 4:
+			st.underline = UnderlineSingle
+		case 7:
+			st.reverse = true
+		case 9:
+			st.strikethrough = true
+		case 21:
+			st.underline = UnderlineDouble
+		case 22:
+			st.bold, st.faint = false, false
+		case 23:
+			st.italic = false
+		case 24:
+			st.underline = UnderlineNone
+		case 27:
+			st.reverse = false
+		case 29:
+			st.strikethrough = false
+		case 39:
+			st.fg = ""
+		case 49:
+			st.bg = ""
+		case 59:
+			st.underlineColor = ""
+		case 38:
+			var skip int
+			st.fg, skip = parseExtendedColor(params[i+1:])
+			i += skip
+		case 48:
+			var skip int
+			st.bg, skip = parseExtendedColor(params[i+1:])
+			i += skip
+		case 58:
+			var skip int
+			st.underlineColor, skip = parseExtendedColor(params[i+1:])
+			i += skip
+		}
+	}
+}
+
+// parseExtendedColor parses the parameters following SGR 38, 48 or 58,
+// returning the color as hex along with the number of parameters consumed.
+func parseExtendedColor(params []string) (string, int) {
+	if len(params) == 0 {
+		return "", 0
+	}
+	switch params[0] {
+	case "5":
+		if len(params) < 2 {
+			return "", len(params)
+		}
+		n, err := strconv.Atoi(params[1])
+		if err != nil || n < 0 || n > 255 {
+			return "", 2
+		}
+		return termenv.ANSI256Color(n).String(), 2
+	case "2":
+		if len(params) < 4 {
+			return "", len(params)
+		}
+		var rgb [3]int
+		for i := range rgb {
+			rgb[i], _ = strconv.Atoi(params[i+1])
+		}
+		return fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2]), 4
+	default:
+		return "", 1
+	}
+}
+
+// colors returns the text and background colors of the style, falling back
+// to the given defaults and taking reverse video into account.
+func (st exportStyle) colors(defaultFg, defaultBg string) (fg, bg string) {
+	fg, bg = st.fg, st.bg
+	if fg == "" {
+		fg = defaultFg
+	}
+	if bg == "" {
+		bg = defaultBg
+	}
+	if st.reverse {
+		fg, bg = bg, fg
+	}
+	return fg, bg
+}
+
+// decorated reports whether the style draws anything other than glyphs, so
+// that whitespace in this style still needs to be drawn.
+func (st exportStyle) decorated() bool {
+	return st.underline != UnderlineNone || st.strikethrough
+}
+
+// css returns the inline CSS for the style in HTML output.
+func (st exportStyle) css(defaultFg, defaultBg string) string {
+	var props []string
+	fg, bg := st.colors(defaultFg, defaultBg)
+	if fg != defaultFg {
+		props = append(props, "color:"+fg)
+	}
+	if bg != defaultBg {
+		props = append(props, "background-color:"+bg)
+	}
+	if st.faint {
+		props = append(props, "opacity:0.5")
+	}
+	if css := st.fontCSS(); css != "" {
+		props = append(props, css)
+	}
+	return strings.Join(props, ";")
+}
+
+// fontCSS returns the CSS for the font and text decoration properties of the
+// style, which HTML and SVG share.
+func (st exportStyle) fontCSS() string {
+	var props []string
+	if st.bold {
+		props = append(props, "font-weight:bold")
+	}
+	if st.italic {
+		props = append(props, "font-style:italic")
+	}
+
+	var lines []string
+	if st.underline != UnderlineNone {
+		lines = append(lines, "underline")
+	}
+	if st.strikethrough {
+		lines = append(lines, "line-through")
+	}
+	if len(lines) > 0 {
+		props = append(props, "text-decoration-line:"+strings.Join(lines, " "))
+	}
+
+	switch st.underline {
+	case UnderlineDouble:
+		props = append(props, "text-decoration-style:double")
+	case UnderlineCurly:
+		props = append(props, "text-decoration-style:wavy")
+	case UnderlineDotted:
+		props = append(props, "text-decoration-style:dotted")
+	case UnderlineDashed:
+		props = append(props, "text-decoration-style:dashed")
+	}
+	if st.underline != UnderlineNone && st.underlineColor != "" {
+		props = append(props, "text-decoration-color:"+st.underlineColor)
+	}
+
+	return strings.Join(props, ";")
+}
@@ -0,0 +1,98 @@
+package lipgloss
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestHTML(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.TrueColor)
+	r.SetHasDarkBackground(true)
+
+	tt := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "plain",
+			input:    r.NewStyle().Render("<hi>"),
+			expected: `&lt;hi&gt;`,
+		},
+		{
+			name:     "bold foreground",
+			input:    r.NewStyle().Bold(true).Foreground(Color("#ff0000")).Render("hi"),
+			expected: `<span style="color:#ff0000;font-weight:bold">hi</span>`,
+		},
+		{
+			name:     "ansi background",
+			input:    r.NewStyle().Background(Color("1")).Render("hi"),
+			expected: `<span style="background-color:#800000">hi</span>`,
+		},
+		{
+			name:     "reverse",
+			input:    r.NewStyle().Reverse(true).Render("hi"),
+			expected: `<span style="color:#1c1c1c;background-color:#d0d0d0">hi</span>`,
+		},
+		{
+			name: "curly underline",
+			input: r.NewStyle().
+				UnderlineStyle(UnderlineCurly).
+				UnderlineColor(Color("#00ff00")).
+				UnderlineSpaces(false).
+				Render("hi"),
+			expected: `<span style="text-decoration-line:underline;text-decoration-style:wavy;text-decoration-color:#00ff00">hi</span>`,
+		},
+		{
+			name:     "hyperlink",
+			input:    r.NewStyle().Hyperlink("https://charm.sh/?a=1&b=2").Render("hi"),
+			expected: `<a href="https://charm.sh/?a=1&amp;b=2">hi</a>`,
+		},
+		{
+			name:  "border",
+			input: r.NewStyle().Border(NormalBorder()).BorderForeground(Color("#0000ff")).Render("hi"),
+			expected: strings.Join([]string{
+				`<span style="color:#0000ff">┌──┐</span>`,
+				`<span style="color:#0000ff">│</span>hi<span style="color:#0000ff">│</span>`,
+				`<span style="color:#0000ff">└──┘</span>`,
+			}, "\n"),
+		},
+	}
+
+	for i, tc := range tt {
+		expected := `<pre style="color:#d0d0d0;background-color:#1c1c1c">` + tc.expected + `</pre>`
+		res := r.HTML(tc.input)
+		if res != expected {
+			t.Errorf("Test %d (%s), expected:\n\n`%s`\n\nActual output:\n\n`%s`\n\n",
+				i, tc.name, expected, res)
+		}
+	}
+}
+
+func TestSVG(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.TrueColor)
+	r.SetHasDarkBackground(false)
+
+	res := r.SVG(r.NewStyle().Background(Color("#ff0000")).Render("a") + " b\nc")
+
+	expected := strings.Join([]string{
+		`<svg xmlns="http://www.w3.org/2000/svg" width="45.2" height="56" viewBox="0 0 45.2 56">`,
+		`<rect width="100%" height="100%" fill="#ffffff"/>`,
+		`<g font-family="monospace" font-size="14" fill="#1c1c1c" xml:space="preserve">`,
+		`<rect x="10" y="10" width="8.4" height="18" fill="#ff0000"/>`,
+		`<text x="10" y="24" textLength="8.4" lengthAdjust="spacingAndGlyphs">a</text>`,
+		`<text x="18.4" y="24" textLength="16.8" lengthAdjust="spacingAndGlyphs"> b</text>`,
+		`<text x="10" y="42" textLength="8.4" lengthAdjust="spacingAndGlyphs">c</text>`,
+		`</g>`,
+		`</svg>`,
+	}, "\n")
+
+	if res != expected {
+		t.Errorf("Expected:\n\n%s\n\nActual output:\n\n%s\n\n", expected, res)
+	}
+}
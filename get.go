@@ -2,8 +2,6 @@ package lipgloss
 
 import (
 	"strings"
-
-	"github.com/muesli/reflow/ansi"
 )
 
 // GetBold returns the style's bold value. If no value is set false is returned.
@@ -23,6 +21,25 @@ func (s Style) GetUnderline() bool {
 	return s.getAsBool(underlineKey, false)
 }
 
+// GetUnderlineStyle returns the style's underline style. If underlining is
+// off UnderlineNone is returned, and if it's on without an explicit style
+// UnderlineSingle is returned.
+func (s Style) GetUnderlineStyle() UnderlineStyle {
+	if !s.getAsBool(underlineKey, false) {
+		return UnderlineNone
+	}
+	if u := s.getAsUnderlineStyle(underlineStyleKey); u != UnderlineNone {
+		return u
+	}
+	return UnderlineSingle
+}
+
+// GetUnderlineColor returns the style's underline color. If no value is set
+// NoColor{} is returned.
+func (s Style) GetUnderlineColor() TerminalColor {
+	return s.getAsColor(underlineColorKey)
+}
+
 // GetStrikethrough returns the style's strikethrough value. If no value is set false
 // is returned.
 func (s Style) GetStrikethrough() bool {
@@ -101,6 +118,18 @@ func (s Style) GetAlignVertical() Position {
 	return v
 }
 
+// GetJustify returns the style's justify rule. If no value is set false is
+// returned.
+func (s Style) GetJustify() bool {
+	return s.getAsBool(justifyKey, false)
+}
+
+// GetHyphenate returns the style's hyphenate rule. If no value is set false
+// is returned.
+func (s Style) GetHyphenate() bool {
+	return s.getAsBool(hyphenateKey, false)
+}
+
 // GetPadding returns the style's top, right, bottom, and left padding values,
 // in that order. 0 is returned for unset values.
 func (s Style) GetPadding() (top, right, bottom, left int) {
@@ -287,6 +316,42 @@ func (s Style) GetBorderLeftBackground() TerminalColor {
 	return s.getAsColor(borderLeftBackgroundKey)
 }
 
+// GetBorderTitle returns the style's border title. If no value is set an
+// empty string is returned.
+func (s Style) GetBorderTitle() string {
+	return s.getAsString(borderTitleKey)
+}
+
+// GetBorderTitleAlign returns the style's border title alignment. If no value
+// is set Position.Left is returned.
+func (s Style) GetBorderTitleAlign() Position {
+	return s.getAsPosition(borderTitleAlignKey)
+}
+
+// GetBorderTitleStyle returns the style used to render the border title. If
+// no value is set an empty Style is returned.
+func (s Style) GetBorderTitleStyle() Style {
+	return s.getAsStyle(borderTitleStyleKey)
+}
+
+// GetBorderFooter returns the style's border footer. If no value is set an
+// empty string is returned.
+func (s Style) GetBorderFooter() string {
+	return s.getAsString(borderFooterKey)
+}
+
+// GetBorderFooterAlign returns the style's border footer alignment. If no
+// value is set Position.Left is returned.
+func (s Style) GetBorderFooterAlign() Position {
+	return s.getAsPosition(borderFooterAlignKey)
+}
+
+// GetBorderFooterStyle returns the style used to render the border footer. If
+// no value is set an empty Style is returned.
+func (s Style) GetBorderFooterStyle() Style {
+	return s.getAsStyle(borderFooterStyleKey)
+}
+
 // GetBorderTopWidth returns the width of the top border. If borders contain
 // runes of varying widths, the widest rune is returned. If no border exists on
 // the top edge, 0 is returned.
@@ -368,6 +433,27 @@ func (s Style) GetMaxHeight() int {
 	return s.getAsInt(maxHeightKey)
 }
 
+// GetEllipsis returns the style's ellipsis. If no value is set an empty
+// string is returned.
+func (s Style) GetEllipsis() string {
+	return s.getAsString(ellipsisKey)
+}
+
+// GetEllipsisPosition returns where the style cuts off text that's too wide.
+// If no value is set Right is returned.
+func (s Style) GetEllipsisPosition() Position {
+	if !s.isSet(ellipsisPositionKey) {
+		return Right
+	}
+	return s.getAsPosition(ellipsisPositionKey)
+}
+
+// GetMaxHeightIndicator returns the style's max height indicator format. If
+// no value is set an empty string is returned.
+func (s Style) GetMaxHeightIndicator() string {
+	return s.getAsString(maxHeightIndicatorKey)
+}
+
 // GetTabWidth returns the style's tab width setting. If no value is set 4 is
 // returned which is the implicit default.
 func (s Style) GetTabWidth() int {
@@ -414,85 +500,282 @@ func (s Style) GetTransform() func(string) string {
 	return s.getAsTransform(transformKey)
 }
 
+// GetHyperlink returns the style's hyperlink URL and params. If no value is
+// set empty strings are returned.
+func (s Style) GetHyperlink() (url, params string) {
+	link := s.getAsHyperlink(hyperlinkKey)
+	return link.url, link.params
+}
+
 // Returns whether or not the given property is set.
-func (s Style) isSet(k propKey) bool {
-	_, exists := s.rules[k]
-	return exists
+func (s *Style) isSet(k propKey) bool {
+	return s.props.has(k)
 }
 
-func (s Style) getAsBool(k propKey, defaultVal bool) bool {
-	v, ok := s.rules[k]
-	if !ok {
+func (s *Style) getAsBool(k propKey, defaultVal bool) bool {
+	if !s.isSet(k) {
 		return defaultVal
 	}
-	if b, ok := v.(bool); ok {
-		return b
-	}
-	return defaultVal
+	return s.attrs.has(k)
 }
 
-func (s Style) getAsColor(k propKey) TerminalColor {
-	v, ok := s.rules[k]
-	if !ok {
+func (s *Style) getAsColor(k propKey) TerminalColor {
+	if !s.isSet(k) {
 		return noColor
 	}
-	if c, ok := v.(TerminalColor); ok {
-		return c
+
+	var c TerminalColor
+	switch k { //nolint:exhaustive
+	case foregroundKey:
+		c = s.fgColor
+	case backgroundKey:
+		c = s.bgColor
+	case marginBackgroundKey:
+		c = s.marginBgColor
+	case borderTopForegroundKey:
+		c = s.borderTopFgColor
+	case borderRightForegroundKey:
+		c = s.borderRightFgColor
+	case borderBottomForegroundKey:
+		c = s.borderBottomFgColor
+	case borderLeftForegroundKey:
+		c = s.borderLeftFgColor
+	case borderTopBackgroundKey:
+		c = s.borderTopBgColor
+	case borderRightBackgroundKey:
+		c = s.borderRightBgColor
+	case borderBottomBackgroundKey:
+		c = s.borderBottomBgColor
+	case borderLeftBackgroundKey:
+		c = s.borderLeftBgColor
+	case underlineColorKey:
+		c = s.underlineColor
+	}
+	if c == nil {
+		return noColor
 	}
-	return noColor
+	return c
 }
 
-func (s Style) getAsInt(k propKey) int {
-	v, ok := s.rules[k]
-	if !ok {
+func (s *Style) getAsInt(k propKey) int {
+	if !s.isSet(k) {
 		return 0
 	}
-	if i, ok := v.(int); ok {
-		return i
+
+	switch k { //nolint:exhaustive
+	case widthKey:
+		return s.width
+	case heightKey:
+		return s.height
+	case paddingTopKey:
+		return s.paddingTop
+	case paddingRightKey:
+		return s.paddingRight
+	case paddingBottomKey:
+		return s.paddingBottom
+	case paddingLeftKey:
+		return s.paddingLeft
+	case marginTopKey:
+		return s.marginTop
+	case marginRightKey:
+		return s.marginRight
+	case marginBottomKey:
+		return s.marginBottom
+	case marginLeftKey:
+		return s.marginLeft
+	case maxWidthKey:
+		return s.maxWidth
+	case maxHeightKey:
+		return s.maxHeight
+	case tabWidthKey:
+		return s.tabWidth
 	}
 	return 0
 }
 
-func (s Style) getAsPosition(k propKey) Position {
-	v, ok := s.rules[k]
-	if !ok {
+func (s *Style) getAsPosition(k propKey) Position {
+	if !s.isSet(k) {
 		return Position(0)
 	}
-	if p, ok := v.(Position); ok {
-		return p
+
+	switch k { //nolint:exhaustive
+	case alignHorizontalKey:
+		return s.alignHorizontal
+	case alignVerticalKey:
+		return s.alignVertical
+	case borderTitleAlignKey:
+		return s.borderTitleAlign
+	case borderFooterAlignKey:
+		return s.borderFooterAlign
+	case ellipsisPositionKey:
+		return s.ellipsisPosition
 	}
 	return Position(0)
 }
 
-func (s Style) getBorderStyle() Border {
-	v, ok := s.rules[borderStyleKey]
-	if !ok {
+func (s *Style) getAsUnderlineStyle(k propKey) UnderlineStyle {
+	if !s.isSet(k) {
+		return UnderlineNone
+	}
+	return s.underlineStyle
+}
+
+func (s *Style) getAsString(k propKey) string {
+	if !s.isSet(k) {
+		return ""
+	}
+
+	switch k { //nolint:exhaustive
+	case borderTitleKey:
+		return s.borderTitle
+	case borderFooterKey:
+		return s.borderFooter
+	case ellipsisKey:
+		return s.ellipsis
+	case maxHeightIndicatorKey:
+		return s.maxHeightIndicator
+	}
+	return ""
+}
+
+func (s *Style) getAsStyle(k propKey) Style {
+	if !s.isSet(k) {
+		return Style{r: s.r}
+	}
+
+	var st *Style
+	switch k { //nolint:exhaustive
+	case borderTitleStyleKey:
+		st = s.borderTitleStyle
+	case borderFooterStyleKey:
+		st = s.borderFooterStyle
+	}
+	if st == nil {
+		return Style{r: s.r}
+	}
+	return *st
+}
+
+func (s *Style) getBorderStyle() Border {
+	if !s.isSet(borderStyleKey) {
 		return noBorder
 	}
-	if b, ok := v.(Border); ok {
-		return b
+	return s.borderStyle
+}
+
+func (s *Style) getAsTransform(k propKey) func(string) string {
+	if !s.isSet(k) {
+		return nil
 	}
-	return noBorder
+	return s.transform
 }
 
-func (s Style) getAsTransform(k propKey) func(string) string {
-	v, ok := s.rules[k]
-	if !ok {
+func (s *Style) getAsHyperlink(k propKey) hyperlink {
+	if !s.isSet(k) {
+		return hyperlink{}
+	}
+	return s.link
+}
+
+// get returns the value of a property as it was set, for when properties are
+// handled generically, such as when encoding styles. It's nil if the property
+// isn't set.
+func (s *Style) get(k propKey) interface{} {
+	if !s.isSet(k) {
 		return nil
 	}
-	if fn, ok := v.(func(string) string); ok {
-		return fn
+
+	switch k { //nolint:exhaustive
+	case foregroundKey:
+		return s.fgColor
+	case backgroundKey:
+		return s.bgColor
+	case widthKey:
+		return s.width
+	case heightKey:
+		return s.height
+	case alignHorizontalKey:
+		return s.alignHorizontal
+	case alignVerticalKey:
+		return s.alignVertical
+	case paddingTopKey:
+		return s.paddingTop
+	case paddingRightKey:
+		return s.paddingRight
+	case paddingBottomKey:
+		return s.paddingBottom
+	case paddingLeftKey:
+		return s.paddingLeft
+	case marginTopKey:
+		return s.marginTop
+	case marginRightKey:
+		return s.marginRight
+	case marginBottomKey:
+		return s.marginBottom
+	case marginLeftKey:
+		return s.marginLeft
+	case marginBackgroundKey:
+		return s.marginBgColor
+	case borderStyleKey:
+		return s.borderStyle
+	case borderTopForegroundKey:
+		return s.borderTopFgColor
+	case borderRightForegroundKey:
+		return s.borderRightFgColor
+	case borderBottomForegroundKey:
+		return s.borderBottomFgColor
+	case borderLeftForegroundKey:
+		return s.borderLeftFgColor
+	case borderTopBackgroundKey:
+		return s.borderTopBgColor
+	case borderRightBackgroundKey:
+		return s.borderRightBgColor
+	case borderBottomBackgroundKey:
+		return s.borderBottomBgColor
+	case borderLeftBackgroundKey:
+		return s.borderLeftBgColor
+	case borderTitleKey:
+		return s.borderTitle
+	case borderTitleAlignKey:
+		return s.borderTitleAlign
+	case borderTitleStyleKey, borderFooterStyleKey:
+		return s.getAsStyle(k)
+	case borderFooterKey:
+		return s.borderFooter
+	case borderFooterAlignKey:
+		return s.borderFooterAlign
+	case maxWidthKey:
+		return s.maxWidth
+	case maxHeightKey:
+		return s.maxHeight
+	case tabWidthKey:
+		return s.tabWidth
+	case transformKey:
+		return s.transform
+	case hyperlinkKey:
+		return s.link
+	case underlineStyleKey:
+		return s.underlineStyle
+	case underlineColorKey:
+		return s.underlineColor
+	case ellipsisKey:
+		return s.ellipsis
+	case ellipsisPositionKey:
+		return s.ellipsisPosition
+	case maxHeightIndicatorKey:
+		return s.maxHeightIndicator
+	default:
+		return s.attrs.has(k)
 	}
-	return nil
 }
 
 // Split a string into lines, additionally returning the size of the widest
 // line.
-func getLines(s string) (lines []string, widest int) {
+func getLines(r *Renderer, s string) (lines []string, widest int) {
 	lines = strings.Split(s, "\n")
 
 	for _, l := range lines {
-		w := ansi.PrintableRuneWidth(l)
+		w := printableWidth(r, l)
 		if widest < w {
 			widest = w
 		}
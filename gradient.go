@@ -0,0 +1,177 @@
+package lipgloss
+
+import (
+	"math"
+	"strings"
+
+	"github.com/lucasb-eyer/go-colorful"
+	"github.com/muesli/termenv"
+)
+
+// Gradient is a color that blends between two or more colors across a block
+// of text: from left to right, or from top to bottom if Vertical is set. The
+// colors are spaced evenly and blended in the CIE L*u*v* color space, which
+// gives smoother results than blending RGB values.
+//
+// Gradients can be used for foregrounds, backgrounds and borders:
+//
+//	var style = lipgloss.NewStyle().
+//	    Foreground(lipgloss.HorizontalGradient(
+//	        lipgloss.Color("#F25D94"),
+//	        lipgloss.Color("#EDFF82"),
+//	    )).
+//	    Border(lipgloss.RoundedBorder()).
+//	    BorderForeground(lipgloss.VerticalGradient(
+//	        lipgloss.Color("#643AFF"),
+//	        lipgloss.Color("#14F9D5"),
+//	    ))
+//
+// The blended colors are degraded to the renderer's color profile like any
+// other color. Where a single color is needed, the color at the middle of the
+// gradient is used.
+type Gradient struct {
+	Colors   []TerminalColor
+	Vertical bool
+}
+
+// HorizontalGradient returns a gradient blending between the given colors
+// from left to right.
+func HorizontalGradient(colors ...TerminalColor) Gradient {
+	return Gradient{Colors: colors}
+}
+
+// VerticalGradient returns a gradient blending between the given colors from
+// top to bottom.
+func VerticalGradient(colors ...TerminalColor) Gradient {
+	return Gradient{Colors: colors, Vertical: true}
+}
+
+func (g Gradient) color(r *Renderer) termenv.Color {
+	return g.at(r, 0.5).color(r) //nolint:gomnd
+}
+
+// RGBA returns the RGBA value of the color at the middle of the gradient.
+// This satisfies the Go Color interface.
+//
+// Deprecated.
+func (g Gradient) RGBA() (uint32, uint32, uint32, uint32) {
+	return termenv.ConvertToRGB(g.color(renderer)).RGBA()
+}
+
+// at returns the color at t, where 0 is the start of the gradient and 1 the
+// end.
+func (g Gradient) at(r *Renderer, t float64) TerminalColor {
+	switch len(g.Colors) {
+	case 0:
+		return noColor
+	case 1:
+		return g.Colors[0]
+	}
+
+	t = math.Min(1, math.Max(0, t))
+	segment := t * float64(len(g.Colors)-1)
+	i := int(math.Min(math.Floor(segment), float64(len(g.Colors)-2))) //nolint:gomnd
+
+	from := toColorful(r, g.Colors[i])
+	to := toColorful(r, g.Colors[i+1])
+	return Color(from.BlendLuv(to, segment-float64(i)).Clamped().Hex())
+}
+
+// asGradient returns c as a gradient, or nil if it's a plain color.
+func asGradient(c TerminalColor) *Gradient {
+	if g, ok := c.(Gradient); ok && len(g.Colors) > 0 {
+		return &Gradient{Colors: g.Colors, Vertical: g.Vertical}
+	}
+	return nil
+}
+
+// position returns where a cell at x, y in a block of the given size falls
+// along the gradient.
+func (g Gradient) position(x, y, width, height int) float64 {
+	pos, size := x, width
+	if g.Vertical {
+		pos, size = y, height
+	}
+	if size < 2 { //nolint:gomnd
+		return 0
+	}
+	return float64(pos) / float64(size-1)
+}
+
+// toColorful converts a color to its full precision RGB value, regardless of
+// the renderer's color profile, so that colors can be blended accurately.
+func toColorful(r *Renderer, c TerminalColor) colorful.Color {
+	return termenv.ConvertToRGB(c.color(r.trueColor()))
+}
+
+// trueColor returns a renderer like r, but with the TrueColor profile, for
+// resolving colors at full precision. It's made the first time it's needed,
+// and again after the background or theme change.
+func (r *Renderer) trueColor() *Renderer {
+	r.mtx.RLock()
+	tc := r.trueColorRenderer
+	r.mtx.RUnlock()
+	if tc != nil {
+		return tc
+	}
+
+	tc = &Renderer{
+		colorProfile:            termenv.TrueColor,
+		explicitColorProfile:    true,
+		hasDarkBackground:       r.HasDarkBackground(),
+		explicitBackgroundColor: true,
+		theme:                   r.Theme(),
+	}
+	r.mtx.Lock()
+	r.trueColorRenderer = tc
+	r.mtx.Unlock()
+	return tc
+}
+
+// gradientLine colors every cell of line y of a block of text of the given
+// size with the given foreground and background gradients, either of which
+// may be nil. Colors are re-applied after any SGR sequence in the text, so
+// that they survive resets.
+func gradientLine(r *Renderer, line string, y, width, height int, fg, bg *Gradient) string {
+	var (
+		b       strings.Builder
+		x       int
+		current string
+		seg     = newSegmenter(r, line)
+	)
+	for seg.next() {
+		if seg.esc {
+			b.WriteString(seg.text)
+			if isSGR(seg.text) {
+				current = ""
+			}
+			continue
+		}
+
+		var params []string
+		if fg != nil {
+			c := fg.at(r, fg.position(x, y, width, height))
+			if seq := c.color(r).Sequence(false); seq != "" {
+				params = append(params, seq)
+			}
+		}
+		if bg != nil {
+			c := bg.at(r, bg.position(x, y, width, height))
+			if seq := c.color(r).Sequence(true); seq != "" {
+				params = append(params, seq)
+			}
+		}
+		if seq := strings.Join(params, ";"); seq != current {
+			b.WriteString(termenv.CSI + seq + "m")
+			current = seq
+		}
+
+		b.WriteString(seg.text)
+		x += seg.width
+	}
+	if current != "" {
+		b.WriteString(sgrReset)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,140 @@
+package lipgloss
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestGradientAt(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.TrueColor)
+
+	g := HorizontalGradient(Color("#ff0000"), Color("#00ff00"), Color("#0000ff"))
+
+	tt := []struct {
+		t        float64
+		expected TerminalColor
+	}{
+		{-1, Color("#ff0000")},
+		{0, Color("#ff0000")},
+		{0.5, Color("#00ff00")},
+		{1, Color("#0000ff")},
+		{2, Color("#0000ff")},
+	}
+
+	for i, tc := range tt {
+		res := g.at(r, tc.t)
+		if res != tc.expected {
+			t.Errorf("Test %d, expected %v, got %v", i, tc.expected, res)
+		}
+	}
+
+	// A single color is a solid color.
+	if res := HorizontalGradient(Color("1")).at(r, 0.5); res != Color("1") {
+		t.Errorf("Expected a single color to be used as is, got %v", res)
+	}
+}
+
+func TestGradientRender(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.TrueColor)
+
+	tt := []struct {
+		name     string
+		style    Style
+		input    string
+		expected string
+	}{
+		{
+			"horizontal foreground",
+			r.NewStyle().Foreground(HorizontalGradient(Color("#ff0000"), Color("#0000ff"))),
+			"abc",
+			"\x1b[38;2;255;0;0ma\x1b[38;2;190;0;144mb\x1b[38;2;0;0;255mc\x1b[0m",
+		},
+		{
+			"vertical background",
+			r.NewStyle().Background(VerticalGradient(Color("#ff0000"), Color("#0000ff"))),
+			"ab\ncd",
+			"\x1b[48;2;255;0;0mab\x1b[0m\n\x1b[48;2;0;0;255mcd\x1b[0m",
+		},
+		{
+			"reapplied after styling",
+			r.NewStyle().Bold(true).Foreground(VerticalGradient(Color("#ff0000"), Color("#0000ff"))),
+			"a\nb",
+			"\x1b[1m\x1b[38;2;255;0;0ma\x1b[0m\n\x1b[1m\x1b[38;2;0;0;255mb\x1b[0m",
+		},
+		{
+			"vertical border",
+			r.NewStyle().
+				Border(NormalBorder()).
+				BorderForeground(VerticalGradient(Color("#ff0000"), Color("#0000ff"))),
+			"a",
+			"\x1b[38;2;255;0;0m┌─┐\x1b[0m\n" +
+				"\x1b[38;2;190;0;144m│\x1b[0ma\x1b[38;2;190;0;144m│\x1b[0m\n" +
+				"\x1b[38;2;0;0;255m└─┘\x1b[0m",
+		},
+		{
+			"horizontal border",
+			r.NewStyle().
+				Border(NormalBorder(), false, true).
+				BorderForeground(HorizontalGradient(Color("#ff0000"), Color("#0000ff"))),
+			"a",
+			"\x1b[38;2;255;0;0m│\x1b[0ma\x1b[38;2;0;0;255m│\x1b[0m",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := tc.style.Render(tc.input)
+			if res != tc.expected {
+				t.Errorf("Expected:\n\n`%s`\n`%s`\n\nActual output:\n\n`%s`\n`%s`\n\n",
+					tc.expected, formatEscapes(tc.expected),
+					res, formatEscapes(res))
+			}
+		})
+	}
+}
+
+func TestGradientProfile(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.ANSI256)
+
+	g := HorizontalGradient(Color("#ff0000"), Color("#0000ff"))
+	res := r.NewStyle().Foreground(g).Render("ab")
+	expected := "\x1b[38;5;196ma\x1b[38;5;21mb\x1b[0m"
+
+	if res != expected {
+		t.Errorf("Expected:\n\n`%s`\n\nActual output:\n\n`%s`\n\n",
+			formatEscapes(expected), formatEscapes(res))
+	}
+}
+
+func TestGradientBorderDerivedColor(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.TrueColor)
+
+	g := HorizontalGradient(Color("#ff0000"), Color("#0000ff"))
+	res := r.NewStyle().
+		Border(NormalBorder()).
+		BorderForeground(Lighten(g, 0.1)).
+		BorderBackground(VerticalGradient(Color("#000000"), Color("#ffffff"))).
+		Render("ab")
+
+	// The colors of each edge are the same all the way along, so each edge is
+	// styled in one go.
+	lines := strings.Split(res, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got:\n\n%s", formatEscapes(res))
+	}
+	for _, i := range []int{0, 2} {
+		if n := strings.Count(lines[i], "\x1b[0m"); n != 1 {
+			t.Errorf("expected line %d to be styled in one go, got `%s`", i, formatEscapes(lines[i]))
+		}
+	}
+	if lines[0] == lines[2] {
+		t.Errorf("expected the top and bottom edges to have different backgrounds")
+	}
+}
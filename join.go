@@ -3,8 +3,6 @@ package lipgloss
 import (
 	"math"
 	"strings"
-
-	"github.com/muesli/reflow/ansi"
 )
 
 // JoinHorizontal is a utility function for horizontally joining two
@@ -46,7 +44,7 @@ func JoinHorizontal(pos Position, strs ...string) string {
 
 	// Break text blocks into lines and get max widths for each text block
 	for i, str := range strs {
-		blocks[i], maxWidths[i] = getLines(str)
+		blocks[i], maxWidths[i] = getLines(nil, str)
 		if len(blocks[i]) > maxHeight {
 			maxHeight = len(blocks[i])
 		}
@@ -85,7 +83,7 @@ func JoinHorizontal(pos Position, strs ...string) string {
 			b.WriteString(block[i])
 
 			// Also make lines the same length
-			b.WriteString(strings.Repeat(" ", maxWidths[j]-ansi.PrintableRuneWidth(block[i])))
+			b.WriteString(strings.Repeat(" ", maxWidths[j]-printableWidth(nil, block[i])))
 		}
 		if i < len(blocks[0])-1 {
 			b.WriteRune('\n')
@@ -128,7 +126,7 @@ func JoinVertical(pos Position, strs ...string) string {
 
 	for i := range strs {
 		var w int
-		blocks[i], w = getLines(strs[i])
+		blocks[i], w = getLines(nil, strs[i])
 		if w > maxWidth {
 			maxWidth = w
 		}
@@ -137,7 +135,7 @@ func JoinVertical(pos Position, strs ...string) string {
 	var b strings.Builder
 	for i, block := range blocks {
 		for j, line := range block {
-			w := maxWidth - ansi.PrintableRuneWidth(line)
+			w := maxWidth - printableWidth(nil, line)
 
 			switch pos { //nolint:exhaustive
 			case Left:
@@ -0,0 +1,116 @@
+package list
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Enumerator enumerates a list. Given a list of items and the index of the
+// current enumeration, it returns the prefix that should be displayed for the
+// current item.
+//
+// For example, a simple Arabic numeral enumeration would be:
+//
+//	func Arabic(_ list.Items, i int) string {
+//		return fmt.Sprintf("%d.", i+1)
+//	}
+//
+// There are several predefined enumerators:
+//   - Alphabet
+//   - Arabic
+//   - Bullet
+//   - Dash
+//   - Roman
+//   - Asterisk
+type Enumerator func(items Items, index int) string
+
+// Alphabet is the enumeration for alphabetical listing.
+//
+//	list.New("Foo", "Bar", "Baz", "Qux").Enumerator(list.Alphabet)
+//
+//	// A. Foo
+//	// B. Bar
+//	// C. Baz
+//	// D. Qux
+func Alphabet(_ Items, i int) string {
+	const abcLen = 26
+
+	var b []byte
+	for n := i + 1; n > 0; n = (n - 1) / abcLen {
+		b = append([]byte{byte('A' + (n-1)%abcLen)}, b...)
+	}
+	return string(b) + "."
+}
+
+// Arabic is the enumeration for arabic numerals listing.
+//
+//	list.New("Foo", "Bar", "Baz", "Qux").Enumerator(list.Arabic)
+//
+//	// 1. Foo
+//	// 2. Bar
+//	// 3. Baz
+//	// 4. Qux
+func Arabic(_ Items, i int) string {
+	return fmt.Sprintf("%d.", i+1)
+}
+
+// Roman is the enumeration for roman numerals listing.
+//
+//	list.New("Foo", "Bar", "Baz", "Qux").Enumerator(list.Roman)
+//
+//	//   I. Foo
+//	//  II. Bar
+//	// III. Baz
+//	//  IV. Qux
+func Roman(_ Items, i int) string {
+	var (
+		roman  = []string{"M", "CM", "D", "CD", "C", "XC", "L", "XL", "X", "IX", "V", "IV", "I"}
+		arabic = []int{1000, 900, 500, 400, 100, 90, 50, 40, 10, 9, 5, 4, 1} //nolint:gomnd
+		result strings.Builder
+		n      = i + 1
+	)
+	for v, a := range arabic {
+		for n >= a {
+			n -= a
+			result.WriteString(roman[v])
+		}
+	}
+	result.WriteRune('.')
+	return result.String()
+}
+
+// Bullet is the enumeration for bullet listing.
+//
+//	list.New("Foo", "Bar", "Baz", "Qux").Enumerator(list.Bullet)
+//
+//	// • Foo
+//	// • Bar
+//	// • Baz
+//	// • Qux
+func Bullet(Items, int) string {
+	return "•"
+}
+
+// Asterisk is an enumeration using asterisks.
+//
+//	list.New("Foo", "Bar", "Baz", "Qux").Enumerator(list.Asterisk)
+//
+//	// * Foo
+//	// * Bar
+//	// * Baz
+//	// * Qux
+func Asterisk(Items, int) string {
+	return "*"
+}
+
+// Dash is an enumeration using dashes.
+//
+//	list.New("Foo", "Bar", "Baz", "Qux").Enumerator(list.Dash)
+//
+//	// - Foo
+//	// - Bar
+//	// - Baz
+//	// - Qux
+func Dash(Items, int) string {
+	return "-"
+}
@@ -0,0 +1,251 @@
+// Package list allows you to build lists, as simple or complicated as you
+// need.
+//
+// Simply, define a list with some items and set its rendering properties,
+// like enumerator and styling:
+//
+//	groceries := list.New(
+//	    "Bananas",
+//	    "Barley",
+//	    "Cashews",
+//	    "Milk",
+//	    list.New(
+//	        "Almond Milk",
+//	        "Coconut Milk",
+//	        "Full Fat Milk",
+//	    ),
+//	    "Eggs",
+//	).Enumerator(list.Roman)
+//
+//	fmt.Println(groceries)
+//
+// Lists are rendered with the tree package, so nested lists, per-item
+// styling and multi-line items all work the same way.
+package list
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/tree"
+)
+
+// List represents a list of items that can be displayed. Lists can contain
+// lists as items, they will be rendered as nested (sub)lists.
+//
+// In fact, lists can contain anything as items, like a table.Table or a
+// tree.Tree.
+type List struct {
+	tree       *tree.Tree
+	enumerator Enumerator
+}
+
+// New returns a new list with the given items.
+//
+//	alphabet := list.New(
+//	    "A",
+//	    "B",
+//	    "C",
+//	    "D",
+//	    "E",
+//	    "F",
+//	    ...
+//	)
+//
+// Items can be other lists, trees, tables, rendered markdown;
+// anything you want, really.
+func New(items ...interface{}) *List {
+	l := &List{tree: tree.New()}
+	return l.Items(items...).Enumerator(Bullet)
+}
+
+// Items represents the list items.
+type Items tree.Children
+
+// StyleFunc is the style function that determines the style of an item.
+//
+// It takes the list items and index of the list and determines the lipgloss
+// Style to use for that index.
+//
+// Example:
+//
+//	l := list.New().
+//	    Item("Red").
+//	    Item("Green").
+//	    Item("Blue").
+//	    ItemStyleFunc(func(items list.Items, i int) lipgloss.Style {
+//	        switch {
+//	        case i == 0:
+//	            return RedStyle
+//	        case i == 1:
+//	            return GreenStyle
+//	        default:
+//	            return BlueStyle
+//	        }
+//	    })
+type StyleFunc func(items Items, index int) lipgloss.Style
+
+// Hidden returns whether this list is hidden.
+func (l *List) Hidden() bool {
+	return l.tree.Hidden()
+}
+
+// Hide hides this list.
+// If this list is hidden, it will not be shown when rendered.
+func (l *List) Hide(hide bool) *List {
+	l.tree.Hide(hide)
+	return l
+}
+
+// Value returns the value of this node.
+func (l *List) Value() string {
+	return l.tree.Value()
+}
+
+// Children returns the items of this list.
+func (l *List) Children() tree.Children {
+	return l.tree.Children()
+}
+
+// String returns the rendered list.
+func (l *List) String() string {
+	return l.tree.String()
+}
+
+// EnumeratorStyle sets the enumerator style for all enumerators.
+//
+// To set the enumerator style conditionally based on the item value or index,
+// use [EnumeratorStyleFunc].
+func (l *List) EnumeratorStyle(style lipgloss.Style) *List {
+	l.tree.EnumeratorStyle(style)
+	return l
+}
+
+// EnumeratorStyleFunc sets the enumerator style function for the list items.
+//
+// Use this to conditionally set different styles based on the current items,
+// sibling items, or index values (i.e. even or odd).
+//
+// Example:
+//
+//	l := list.New().
+//	    EnumeratorStyleFunc(func(_ list.Items, i int) lipgloss.Style {
+//	        if i == 1 {
+//	            return lipgloss.NewStyle().Foreground(brightPink)
+//	        }
+//	        return lipgloss.NewStyle().Foreground(dimPink)
+//	    })
+func (l *List) EnumeratorStyleFunc(f StyleFunc) *List {
+	if f == nil {
+		l.tree.EnumeratorStyleFunc(nil)
+		return l
+	}
+	l.tree.EnumeratorStyleFunc(func(children tree.Children, index int) lipgloss.Style {
+		return f(children, index)
+	})
+	return l
+}
+
+// ItemStyle sets the item style for all items.
+//
+// To set the item style conditionally based on the item value or index,
+// use [ItemStyleFunc].
+func (l *List) ItemStyle(style lipgloss.Style) *List {
+	l.tree.ItemStyle(style)
+	return l
+}
+
+// ItemStyleFunc sets the item style function for the list items.
+//
+// Use this to conditionally set styles based on the current item, sibling
+// items, or index values.
+//
+// Example:
+//
+//	l := list.New().
+//	    ItemStyleFunc(func(_ list.Items, i int) lipgloss.Style {
+//	        if i == 1 {
+//	            return lipgloss.NewStyle().Foreground(brightPink)
+//	        }
+//	        return lipgloss.NewStyle().Foreground(dimPink)
+//	    })
+func (l *List) ItemStyleFunc(f StyleFunc) *List {
+	if f == nil {
+		l.tree.ItemStyleFunc(nil)
+		return l
+	}
+	l.tree.ItemStyleFunc(func(children tree.Children, index int) lipgloss.Style {
+		return f(children, index)
+	})
+	return l
+}
+
+// Item appends an item to the list. Items can be strings, other lists, or
+// anything else the tree package accepts as a child.
+//
+//	l := list.New().
+//	    Item("Foo").
+//	    Item("Bar").
+//	    Item("Baz")
+func (l *List) Item(item interface{}) *List {
+	switch item := item.(type) {
+	case *List:
+		l.tree.Child(item.tree)
+	default:
+		l.tree.Child(item)
+	}
+	return l
+}
+
+// Items appends multiple items to the list.
+//
+//	l := list.New().
+//	    Items("Foo", "Bar", "Baz")
+func (l *List) Items(items ...interface{}) *List {
+	for _, item := range items {
+		l.Item(item)
+	}
+	return l
+}
+
+// Enumerator sets the list enumerator.
+//
+// There are several predefined enumerators:
+//   - Alphabet
+//   - Arabic
+//   - Bullet
+//   - Dash
+//   - Roman
+//   - Asterisk
+//
+// Or, define your own.
+//
+//	func enumerator(items list.Items, index int) string {
+//	    if index == 5 {
+//	        return "*"
+//	    }
+//	    return "•"
+//	}
+//
+//	l := list.New().
+//	    Items("Foo", "Bar", "Baz").
+//	    Enumerator(enumerator)
+func (l *List) Enumerator(enumerator Enumerator) *List {
+	l.enumerator = enumerator
+	l.tree.Enumerator(func(children tree.Children, index int) string {
+		return enumerator(children, index)
+	})
+
+	// Indent nested lists and multi-line items to line up with the text of
+	// the items, which starts after the widest enumerator.
+	l.tree.Indenter(func(children tree.Children, _ int) string {
+		var width int
+		for i := 0; i < children.Length(); i++ {
+			if w := lipgloss.Width(l.enumerator(children, i)); w > width {
+				width = w
+			}
+		}
+		return strings.Repeat(" ", width)
+	})
+	return l
+}
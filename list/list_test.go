@@ -0,0 +1,134 @@
+package list
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestList(t *testing.T) {
+	l := New().
+		Item("Foo").
+		Item("Bar").
+		Item("Baz")
+
+	expected := strings.TrimSpace(`
+• Foo
+• Bar
+• Baz
+`)
+
+	if l.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, l.String())
+	}
+}
+
+func TestSublist(t *testing.T) {
+	l := New("Foo", "Bar", New("Hi", "Hello", "Halo").Enumerator(Roman), "Qux")
+
+	expected := strings.TrimSpace(`
+• Foo
+• Bar
+    I. Hi
+   II. Hello
+  III. Halo
+• Qux
+`)
+
+	if l.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, l.String())
+	}
+}
+
+func TestEnumeratorAlignment(t *testing.T) {
+	items := make([]interface{}, 10)
+	for i := range items {
+		items[i] = "Foo"
+	}
+	l := New(items...).Enumerator(Arabic)
+
+	expected := strings.Join([]string{
+		" 1. Foo",
+		" 2. Foo",
+		" 3. Foo",
+		" 4. Foo",
+		" 5. Foo",
+		" 6. Foo",
+		" 7. Foo",
+		" 8. Foo",
+		" 9. Foo",
+		"10. Foo",
+	}, "\n")
+
+	if l.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, l.String())
+	}
+}
+
+func TestMultilineItems(t *testing.T) {
+	l := New("A long item that wraps", "Short").
+		Enumerator(Arabic).
+		ItemStyle(lipgloss.NewStyle().Width(10))
+
+	expected := strings.Join([]string{
+		"1. A long    ",
+		"   item that ",
+		"   wraps     ",
+		"2. Short     ",
+	}, "\n")
+
+	if l.String() != expected {
+		t.Fatalf("expected:\n\n%q\n\ngot:\n\n%q", expected, l.String())
+	}
+}
+
+func TestStyleFuncs(t *testing.T) {
+	l := New("Foo", "Bar").
+		Enumerator(Dash).
+		EnumeratorStyleFunc(func(_ Items, i int) lipgloss.Style {
+			return lipgloss.NewStyle().SetString(strings.Repeat(">", i+1)).PaddingRight(1)
+		}).
+		ItemStyleFunc(func(_ Items, i int) lipgloss.Style {
+			return lipgloss.NewStyle().Transform(strings.ToUpper)
+		})
+
+	expected := strings.Join([]string{
+		" > - FOO",
+		">> - BAR",
+	}, "\n")
+
+	if l.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, l.String())
+	}
+}
+
+func TestEnumerators(t *testing.T) {
+	tt := []struct {
+		enumerator Enumerator
+		index      int
+		expected   string
+	}{
+		{Alphabet, 0, "A."},
+		{Alphabet, 25, "Z."},
+		{Alphabet, 26, "AA."},
+		{Alphabet, 27, "AB."},
+		{Alphabet, 701, "ZZ."},
+		{Alphabet, 702, "AAA."},
+		{Arabic, 9, "10."},
+		{Roman, 0, "I."},
+		{Roman, 3, "IV."},
+		{Roman, 8, "IX."},
+		{Roman, 48, "XLIX."},
+		{Roman, 1993, "MCMXCIV."},
+		{Bullet, 0, "•"},
+		{Dash, 0, "-"},
+		{Asterisk, 0, "*"},
+	}
+
+	for _, tc := range tt {
+		if res := tc.enumerator(nil, tc.index); res != tc.expected {
+			t.Errorf("expected %q for index %d, got %q", tc.expected, tc.index, res)
+		}
+	}
+}
@@ -3,8 +3,6 @@ package lipgloss
 import (
 	"math"
 	"strings"
-
-	"github.com/muesli/reflow/ansi"
 )
 
 // Position represents a position along a horizontal or vertical axis. It's in
@@ -54,7 +52,7 @@ func PlaceHorizontal(width int, pos Position, str string, opts ...WhitespaceOpti
 // block of a given width. If the given width is shorter than the max width of
 // the string (measured by its longest line) this will be a noöp.
 func (r *Renderer) PlaceHorizontal(width int, pos Position, str string, opts ...WhitespaceOption) string {
-	lines, contentWidth := getLines(str)
+	lines, contentWidth := getLines(r, str)
 	gap := width - contentWidth
 
 	if gap <= 0 {
@@ -66,7 +64,7 @@ func (r *Renderer) PlaceHorizontal(width int, pos Position, str string, opts ...
 	var b strings.Builder
 	for i, l := range lines {
 		// Is this line shorter than the longest line?
-		short := max(0, contentWidth-ansi.PrintableRuneWidth(l))
+		short := max(0, contentWidth-printableWidth(r, l))
 
 		switch pos { //nolint:exhaustive
 		case Left:
@@ -117,7 +115,7 @@ func (r *Renderer) PlaceVertical(height int, pos Position, str string, opts ...W
 
 	ws := newWhitespace(r, opts...)
 
-	_, width := getLines(str)
+	_, width := getLines(r, str)
 	emptyLine := ws.render(width)
 	b := strings.Builder{}
 
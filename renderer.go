@@ -25,6 +25,14 @@ type Renderer struct {
 	getBackgroundColor      sync.Once
 	explicitBackgroundColor bool
 
+	theme Theme
+
+	ambiguousWidth AmbiguousWidth
+
+	// A renderer like this one, but with the TrueColor profile. See
+	// trueColor.
+	trueColorRenderer *Renderer
+
 	mtx sync.RWMutex
 }
 
@@ -181,4 +189,5 @@ func (r *Renderer) SetHasDarkBackground(b bool) {
 
 	r.hasDarkBackground = b
 	r.explicitBackgroundColor = true
+	r.trueColorRenderer = nil
 }
@@ -1,12 +1,18 @@
 package lipgloss
 
 import (
+	"sort"
 	"strings"
 )
 
 // StyleRunes apply a given style to runes at the given indices in the string.
 // Note that you must provide styling options for both matched and unmatched
 // runes. Indices out of bounds will be ignored.
+//
+// Indices count grapheme clusters, that is characters as they're shown,
+// rather than individual runes, so that a letter and its combining marks, a
+// flag or an emoji sequence is styled as a whole. For text without such
+// characters, the two are the same.
 func StyleRunes(str string, indices []int, matched, unmatched Style) string {
 	// Convert slice of indices to a map for easier lookups
 	m := make(map[int]struct{})
@@ -18,16 +24,16 @@ func StyleRunes(str string, indices []int, matched, unmatched Style) string {
 		out   strings.Builder
 		group strings.Builder
 		style Style
-		runes = []rune(str)
+		chars = graphemes(str)
 	)
 
-	for i, r := range runes {
-		group.WriteRune(r)
+	for i, c := range chars {
+		group.WriteString(c)
 
 		_, matches := m[i]
 		_, nextMatches := m[i+1]
 
-		if matches != nextMatches || i == len(runes)-1 {
+		if matches != nextMatches || i == len(chars)-1 {
 			// Flush
 			if matches {
 				style = matched
@@ -41,3 +47,140 @@ func StyleRunes(str string, indices []int, matched, unmatched Style) string {
 
 	return out.String()
 }
+
+// Range is a range of characters in a string to style with StyleRanges,
+// from Start up to, but not including, End.
+type Range struct {
+	Start, End int
+	Style      Style
+}
+
+// NewRange returns a range of characters to style with StyleRanges.
+func NewRange(start, end int, style Style) Range {
+	return Range{Start: start, End: end, Style: style}
+}
+
+// StyleRanges styles ranges of characters in a string, each with its own
+// style. It's handy for highlighting several kinds of matches on a line, such
+// as the results of a fuzzy search or the tokens of syntax highlighting.
+//
+// Like with StyleRunes, positions count grapheme clusters, that is
+// characters as they're shown, and escape sequences aren't counted. For
+// ASCII text without escape sequences, positions are byte offsets.
+//
+// Ranges may overlap. Where they do, later ranges take precedence, with
+// styling rules they don't set inherited from earlier ones, as with
+// Style.Inherit. Ranges out of bounds are cut down to size, and empty ranges
+// are ignored.
+//
+// Styling already in the string is kept. Within a range, the range's style
+// is applied on top of it, and it's restored after the range ends. Ranges are
+// meant for inline styling, such as colors and text attributes: layout rules,
+// like padding and width, apply to each styled piece separately.
+//
+// Example:
+//
+//	keyword := lipgloss.NewStyle().Foreground(lipgloss.Color("5"))
+//	match := lipgloss.NewStyle().Underline(true)
+//	str := lipgloss.StyleRanges("func main()",
+//		lipgloss.NewRange(0, 4, keyword),
+//		lipgloss.NewRange(2, 7, match),
+//	)
+func StyleRanges(str string, ranges ...Range) string {
+	// Between any two consecutive boundaries, the same ranges apply.
+	var bounds []int
+	for _, r := range ranges {
+		if r.End > r.Start && r.End > 0 {
+			bounds = append(bounds, max(0, r.Start), r.End)
+		}
+	}
+	if len(bounds) == 0 {
+		return str
+	}
+	sort.Ints(bounds)
+
+	var (
+		out     strings.Builder
+		state   ansiState
+		seg     = newSegmenter(nil, str)
+		pos     int
+		span    = -1 // The index of the span between boundaries we're in.
+		styled  bool // Whether any ranges apply to the current span.
+		style   Style
+		pending strings.Builder
+	)
+
+	// Text is rendered in pieces, broken up by escape sequences, newlines and
+	// changes in the ranges that apply.
+	flush := func() {
+		if pending.Len() == 0 {
+			return
+		}
+		text := pending.String()
+		pending.Reset()
+		if !styled {
+			out.WriteString(text)
+			return
+		}
+		res := style.Render(text)
+		out.WriteString(res)
+		if res != text {
+			// Styling a piece resets everything, so restore the styling
+			// that was already in place.
+			out.WriteString(state.open())
+		}
+	}
+
+	for seg.next() {
+		if seg.esc {
+			flush()
+			state.update(seg.text)
+			out.WriteString(seg.text)
+			continue
+		}
+
+		if span+1 < len(bounds) && pos >= bounds[span+1] {
+			flush()
+			for span+1 < len(bounds) && pos >= bounds[span+1] {
+				span++
+			}
+			style, styled = rangeStyle(ranges, pos)
+		}
+		if seg.text == "\n" {
+			// Lines are styled separately, so that they aren't aligned
+			// with each other.
+			flush()
+			out.WriteString(seg.text)
+		} else {
+			pending.WriteString(seg.text)
+		}
+		pos++
+	}
+	flush()
+
+	return out.String()
+}
+
+// rangeStyle returns the style of the ranges including the given position,
+// or false if there are none.
+func rangeStyle(ranges []Range, pos int) (Style, bool) {
+	var (
+		style Style
+		found bool
+	)
+	for _, r := range ranges {
+		if pos < r.Start || pos >= r.End {
+			continue
+		}
+		if found {
+			style = r.Style.Inherit(style)
+		} else {
+			style, found = r.Style, true
+		}
+	}
+	if found && !style.isSet(tabWidthKey) {
+		// Leave the text as it is.
+		style = style.TabWidth(NoTabConversion)
+	}
+	return style, found
+}
@@ -1,8 +1,11 @@
 package lipgloss
 
 import (
+	"io"
 	"strings"
 	"testing"
+
+	"github.com/muesli/termenv"
 )
 
 func TestStyleRunes(t *testing.T) {
@@ -39,6 +42,18 @@ func TestStyleRunes(t *testing.T) {
 			[]int{1, 3},
 			"h\x1b[7me\x1b[0ml\x1b[7ml\x1b[0mo",
 		},
+		{
+			"combining 0,2",
+			"e\u0301te\u0301",
+			[]int{0, 2},
+			"\x1b[7me\u0301\x1b[0mt\x1b[7me\u0301\x1b[0m",
+		},
+		{
+			"flags 1",
+			"\U0001F1FA\U0001F1F8\U0001F1EC\U0001F1E7",
+			[]int{1},
+			"\U0001F1FA\U0001F1F8\x1b[7m\U0001F1EC\U0001F1E7\x1b[0m",
+		},
 		{
 			"你好 0,1",
 			"你好",
@@ -63,6 +78,80 @@ func TestStyleRunes(t *testing.T) {
 	}
 }
 
+func TestStyleRanges(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.ANSI)
+	bold := r.NewStyle().Bold(true)
+	italic := r.NewStyle().Italic(true)
+	reverse := r.NewStyle().Reverse(true)
+
+	tt := []struct {
+		name     string
+		input    string
+		ranges   []Range
+		expected string
+	}{
+		{
+			"none",
+			"hello",
+			nil,
+			"hello",
+		},
+		{
+			"separate",
+			"hello world",
+			[]Range{NewRange(0, 5, bold), NewRange(6, 11, italic)},
+			"\x1b[1mhello\x1b[0m \x1b[3mworld\x1b[0m",
+		},
+		{
+			"overlapping",
+			"abcdef",
+			[]Range{NewRange(0, 4, bold), NewRange(2, 6, italic)},
+			"\x1b[1mab\x1b[0m\x1b[1;3mcd\x1b[0m\x1b[3mef\x1b[0m",
+		},
+		{
+			"later takes precedence",
+			"abc",
+			[]Range{NewRange(0, 3, bold), NewRange(1, 2, r.NewStyle().Bold(false))},
+			"\x1b[1ma\x1b[0mb\x1b[1mc\x1b[0m",
+		},
+		{
+			"existing styles",
+			"\x1b[31mred\x1b[0m text",
+			[]Range{NewRange(1, 2, bold)},
+			"\x1b[31mr\x1b[1me\x1b[0m\x1b[31md\x1b[0m text",
+		},
+		{
+			"out of bounds",
+			"hello",
+			[]Range{NewRange(3, 2, bold), NewRange(-2, 1, italic), NewRange(4, 100, bold)},
+			"\x1b[3mh\x1b[0mell\x1b[1mo\x1b[0m",
+		},
+		{
+			"lines",
+			"ab\ncd",
+			[]Range{NewRange(1, 4, reverse)},
+			"a\x1b[7mb\x1b[0m\n\x1b[7mc\x1b[0md",
+		},
+		{
+			"grapheme clusters",
+			"e\u0301te\u0301\tx",
+			[]Range{NewRange(1, 4, reverse)},
+			"e\u0301\x1b[7mte\u0301\t\x1b[0mx",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := StyleRanges(tc.input, tc.ranges...)
+			if res != tc.expected {
+				t.Errorf("Expected:\n\n`%s`\n\nActual Output:\n\n`%s`\n\n",
+					formatEscapes(tc.expected), formatEscapes(res))
+			}
+		})
+	}
+}
+
 func formatEscapes(str string) string {
 	return strings.ReplaceAll(str, "\x1b", "\\x1b")
 }
@@ -1,36 +1,225 @@
 package lipgloss
 
-// This could (should) probably just be moved into NewStyle(). We've broken it
-// out, so we can call it in a lazy way.
-func (s *Style) init() {
-	if s.rules == nil {
-		s.rules = make(rules)
-	}
-}
+import (
+	"strings"
+)
 
-// Set a value on the underlying rules map.
+// Set a value on the style. Values of the wrong type are treated as the zero
+// value for the property.
 func (s *Style) set(key propKey, value interface{}) {
-	s.init()
-
-	switch v := value.(type) {
-	case int:
+	switch key { //nolint:exhaustive
+	case foregroundKey:
+		s.fgColor, _ = value.(TerminalColor)
+	case backgroundKey:
+		s.bgColor, _ = value.(TerminalColor)
+	case widthKey:
+		s.width = clampInt(value)
+	case heightKey:
+		s.height = clampInt(value)
+	case alignHorizontalKey:
+		s.alignHorizontal, _ = value.(Position)
+	case alignVerticalKey:
+		s.alignVertical, _ = value.(Position)
+	case paddingTopKey:
+		s.paddingTop = clampInt(value)
+	case paddingRightKey:
+		s.paddingRight = clampInt(value)
+	case paddingBottomKey:
+		s.paddingBottom = clampInt(value)
+	case paddingLeftKey:
+		s.paddingLeft = clampInt(value)
+	case marginTopKey:
+		s.marginTop = clampInt(value)
+	case marginRightKey:
+		s.marginRight = clampInt(value)
+	case marginBottomKey:
+		s.marginBottom = clampInt(value)
+	case marginLeftKey:
+		s.marginLeft = clampInt(value)
+	case marginBackgroundKey:
+		s.marginBgColor, _ = value.(TerminalColor)
+	case borderStyleKey:
+		s.borderStyle, _ = value.(Border)
+	case borderTopForegroundKey:
+		s.borderTopFgColor, _ = value.(TerminalColor)
+	case borderRightForegroundKey:
+		s.borderRightFgColor, _ = value.(TerminalColor)
+	case borderBottomForegroundKey:
+		s.borderBottomFgColor, _ = value.(TerminalColor)
+	case borderLeftForegroundKey:
+		s.borderLeftFgColor, _ = value.(TerminalColor)
+	case borderTopBackgroundKey:
+		s.borderTopBgColor, _ = value.(TerminalColor)
+	case borderRightBackgroundKey:
+		s.borderRightBgColor, _ = value.(TerminalColor)
+	case borderBottomBackgroundKey:
+		s.borderBottomBgColor, _ = value.(TerminalColor)
+	case borderLeftBackgroundKey:
+		s.borderLeftBgColor, _ = value.(TerminalColor)
+	case borderTitleKey:
+		s.borderTitle, _ = value.(string)
+	case borderTitleAlignKey:
+		s.borderTitleAlign, _ = value.(Position)
+	case borderTitleStyleKey:
+		s.borderTitleStyle = stylePtr(value)
+	case borderFooterKey:
+		s.borderFooter, _ = value.(string)
+	case borderFooterAlignKey:
+		s.borderFooterAlign, _ = value.(Position)
+	case borderFooterStyleKey:
+		s.borderFooterStyle = stylePtr(value)
+	case maxWidthKey:
+		s.maxWidth = clampInt(value)
+	case maxHeightKey:
+		s.maxHeight = clampInt(value)
+	case tabWidthKey:
 		// TabWidth is the only property that may have a negative value (and
 		// that negative value can be no less than -1).
-		if key == tabWidthKey {
-			s.rules[key] = v
-			break
+		v, _ := value.(int)
+		s.tabWidth = max(NoTabConversion, v)
+	case transformKey:
+		s.transform, _ = value.(func(string) string)
+	case hyperlinkKey:
+		s.link, _ = value.(hyperlink)
+	case underlineStyleKey:
+		s.underlineStyle, _ = value.(UnderlineStyle)
+	case underlineColorKey:
+		s.underlineColor, _ = value.(TerminalColor)
+	case ellipsisKey:
+		s.ellipsis, _ = value.(string)
+	case ellipsisPositionKey:
+		s.ellipsisPosition, _ = value.(Position)
+	case maxHeightIndicatorKey:
+		s.maxHeightIndicator, _ = value.(string)
+	default:
+		// Everything else is a boolean.
+		if v, _ := value.(bool); v {
+			s.attrs = s.attrs.set(key)
+		} else {
+			s.attrs = s.attrs.unset(key)
 		}
-
-		// We don't allow negative integers on any of our other values, so just keep
-		// them at zero or above. We could use uints instead, but the
-		// conversions are a little tedious, so we're sticking with ints for
-		// sake of usability.
-		s.rules[key] = max(0, v)
+	}
+	s.props = s.props.set(key)
+}
+
+// setFrom sets a property to its value in another style.
+func (s *Style) setFrom(key propKey, i Style) {
+	switch key { //nolint:exhaustive
+	case foregroundKey:
+		s.fgColor = i.fgColor
+	case backgroundKey:
+		s.bgColor = i.bgColor
+	case widthKey:
+		s.width = i.width
+	case heightKey:
+		s.height = i.height
+	case alignHorizontalKey:
+		s.alignHorizontal = i.alignHorizontal
+	case alignVerticalKey:
+		s.alignVertical = i.alignVertical
+	case paddingTopKey:
+		s.paddingTop = i.paddingTop
+	case paddingRightKey:
+		s.paddingRight = i.paddingRight
+	case paddingBottomKey:
+		s.paddingBottom = i.paddingBottom
+	case paddingLeftKey:
+		s.paddingLeft = i.paddingLeft
+	case marginTopKey:
+		s.marginTop = i.marginTop
+	case marginRightKey:
+		s.marginRight = i.marginRight
+	case marginBottomKey:
+		s.marginBottom = i.marginBottom
+	case marginLeftKey:
+		s.marginLeft = i.marginLeft
+	case marginBackgroundKey:
+		s.marginBgColor = i.marginBgColor
+	case borderStyleKey:
+		s.borderStyle = i.borderStyle
+	case borderTopForegroundKey:
+		s.borderTopFgColor = i.borderTopFgColor
+	case borderRightForegroundKey:
+		s.borderRightFgColor = i.borderRightFgColor
+	case borderBottomForegroundKey:
+		s.borderBottomFgColor = i.borderBottomFgColor
+	case borderLeftForegroundKey:
+		s.borderLeftFgColor = i.borderLeftFgColor
+	case borderTopBackgroundKey:
+		s.borderTopBgColor = i.borderTopBgColor
+	case borderRightBackgroundKey:
+		s.borderRightBgColor = i.borderRightBgColor
+	case borderBottomBackgroundKey:
+		s.borderBottomBgColor = i.borderBottomBgColor
+	case borderLeftBackgroundKey:
+		s.borderLeftBgColor = i.borderLeftBgColor
+	case borderTitleKey:
+		s.borderTitle = i.borderTitle
+	case borderTitleAlignKey:
+		s.borderTitleAlign = i.borderTitleAlign
+	case borderTitleStyleKey:
+		s.borderTitleStyle = i.borderTitleStyle
+	case borderFooterKey:
+		s.borderFooter = i.borderFooter
+	case borderFooterAlignKey:
+		s.borderFooterAlign = i.borderFooterAlign
+	case borderFooterStyleKey:
+		s.borderFooterStyle = i.borderFooterStyle
+	case maxWidthKey:
+		s.maxWidth = i.maxWidth
+	case maxHeightKey:
+		s.maxHeight = i.maxHeight
+	case tabWidthKey:
+		s.tabWidth = i.tabWidth
+	case transformKey:
+		s.transform = i.transform
+	case hyperlinkKey:
+		s.link = i.link
+	case underlineStyleKey:
+		s.underlineStyle = i.underlineStyle
+	case underlineColorKey:
+		s.underlineColor = i.underlineColor
+	case ellipsisKey:
+		s.ellipsis = i.ellipsis
+	case ellipsisPositionKey:
+		s.ellipsisPosition = i.ellipsisPosition
+	case maxHeightIndicatorKey:
+		s.maxHeightIndicator = i.maxHeightIndicator
 	default:
-		s.rules[key] = v
+		if i.attrs.has(key) {
+			s.attrs = s.attrs.set(key)
+		} else {
+			s.attrs = s.attrs.unset(key)
+		}
+	}
+	s.props = s.props.set(key)
+}
+
+// unset removes properties from the style.
+func (s *Style) unset(keys ...propKey) {
+	for _, k := range keys {
+		s.props = s.props.unset(k)
 	}
 }
 
+// clampInt returns an integer property value. We don't allow negative
+// integers on any of our values other than TabWidth, so just keep them at
+// zero or above. We could use uints instead, but the conversions are a little
+// tedious, so we're sticking with ints for sake of usability.
+func clampInt(value interface{}) int {
+	v, _ := value.(int)
+	return max(0, v)
+}
+
+// stylePtr returns a style property value as a pointer.
+func stylePtr(value interface{}) *Style {
+	st, ok := value.(Style)
+	if !ok {
+		return nil
+	}
+	return &st
+}
+
 // Bold sets a bold formatting rule.
 func (s Style) Bold(v bool) Style {
 	s.set(boldKey, v)
@@ -52,6 +241,28 @@ func (s Style) Underline(v bool) Style {
 	return s
 }
 
+// UnderlineStyle sets the shape of the underline and enables underlining,
+// unless the style is UnderlineNone, in which case underlining is disabled.
+//
+//	var style = lipgloss.NewStyle().
+//	    UnderlineStyle(lipgloss.UnderlineCurly).
+//	    UnderlineColor(lipgloss.Color("9"))
+//
+// Terminals that don't support extended underlines will usually draw a
+// single underline instead.
+func (s Style) UnderlineStyle(u UnderlineStyle) Style {
+	s.set(underlineStyleKey, u)
+	s.set(underlineKey, u != UnderlineNone)
+	return s
+}
+
+// UnderlineColor sets the color of the underline. When unset, underlines are
+// drawn in the foreground color.
+func (s Style) UnderlineColor(c TerminalColor) Style {
+	s.set(underlineColorKey, c)
+	return s
+}
+
 // Strikethrough sets a strikethrough rule. By default, strikes will not be
 // drawn on whitespace like margins and padding. To change this behavior set
 // StrikethroughSpaces.
@@ -139,6 +350,27 @@ func (s Style) AlignVertical(p Position) Style {
 	return s
 }
 
+// Justify sets a rule for justifying text wrapped by Width. Spaces between
+// words are widened so that each wrapped line fills the width, much like in a
+// printed book. The last line of each paragraph is aligned as usual, according
+// to AlignHorizontal.
+func (s Style) Justify(v bool) Style {
+	s.set(justifyKey, v)
+	return s
+}
+
+// Hyphenate sets a rule for hyphenating words when wrapping text with Width.
+// Words are broken at soft hyphens (U+00AD) where possible, and words too
+// long to fit on a line of their own are broken with a hyphen rather than
+// cut off abruptly.
+//
+//	s := lipgloss.NewStyle().Width(20).Hyphenate(true)
+//	s.Render("super\u00adcal\u00adi\u00adfrag\u00adil\u00adis\u00adtic")
+func (s Style) Hyphenate(v bool) Style {
+	s.set(hyphenateKey, v)
+	return s
+}
+
 // Padding is a shorthand method for setting padding on all sides at once.
 //
 // With one argument, the value is applied to all sides.
@@ -462,6 +694,62 @@ func (s Style) BorderLeftBackground(c TerminalColor) Style {
 	return s
 }
 
+// BorderTitle sets a label to be embedded in the top border. The title is
+// only drawn when the top border is visible and will be truncated with an
+// ellipsis if the box is narrower than the title. Spacing around the title is
+// up to you: either include it in the string or add padding via
+// BorderTitleStyle.
+//
+// Example:
+//
+//	// ╭─ Logs ────────╮
+//	lipgloss.NewStyle().
+//	    Border(lipgloss.RoundedBorder()).
+//	    BorderTitle(" Logs ").
+//	    BorderTitleAlign(0.1)
+func (s Style) BorderTitle(title string) Style {
+	s.set(borderTitleKey, title)
+	return s
+}
+
+// BorderTitleAlign sets the horizontal position of the title along the top
+// border. Use Left, Center and Right, or any value in between.
+func (s Style) BorderTitleAlign(p Position) Style {
+	s.set(borderTitleAlignKey, p)
+	return s
+}
+
+// BorderTitleStyle sets the style used to render the border title. Only
+// single-line, inline formatting is meaningful here, such as colors, bold and
+// horizontal padding.
+func (s Style) BorderTitleStyle(style Style) Style {
+	s.set(borderTitleStyleKey, style)
+	return s
+}
+
+// BorderFooter sets a label to be embedded in the bottom border. The footer is
+// only drawn when the bottom border is visible and will be truncated with an
+// ellipsis if the box is narrower than the footer.
+func (s Style) BorderFooter(footer string) Style {
+	s.set(borderFooterKey, footer)
+	return s
+}
+
+// BorderFooterAlign sets the horizontal position of the footer along the
+// bottom border. Use Left, Center and Right, or any value in between.
+func (s Style) BorderFooterAlign(p Position) Style {
+	s.set(borderFooterAlignKey, p)
+	return s
+}
+
+// BorderFooterStyle sets the style used to render the border footer. Only
+// single-line, inline formatting is meaningful here, such as colors, bold and
+// horizontal padding.
+func (s Style) BorderFooterStyle(style Style) Style {
+	s.set(borderFooterStyleKey, style)
+	return s
+}
+
 // Inline makes rendering output one line and disables the rendering of
 // margins, padding and borders. This is useful when you need a style to apply
 // only to font rendering and don't want it to change any physical dimensions.
@@ -483,7 +771,7 @@ func (s Style) Inline(v bool) Style {
 
 // MaxWidth applies a max width to a given style. This is useful in enforcing
 // a certain width at render time, particularly with arbitrary strings and
-// styles.
+// styles. To mark where lines were cut off, set an Ellipsis.
 //
 // Because this in intended to be used at the time of render, this method will
 // not mutate the style and instead return a copy.
@@ -499,6 +787,39 @@ func (s Style) MaxWidth(n int) Style {
 	return o
 }
 
+// Ellipsis sets a string to show in place of text cut off by MaxWidth, such
+// as "…". If MaxHeight cuts off lines, the ellipsis is shown at the end of the
+// last line that's kept.
+//
+//	var style = lipgloss.NewStyle().MaxWidth(16).Ellipsis("…")
+func (s Style) Ellipsis(e string) Style {
+	s.set(ellipsisKey, e)
+	return s
+}
+
+// EllipsisPosition sets where text is cut off by MaxWidth. Right, the
+// default, cuts off the end of the text. Left cuts off the start, which is
+// handy for file paths, and Center cuts out the middle.
+func (s Style) EllipsisPosition(p Position) Style {
+	s.set(ellipsisPositionKey, p)
+	return s
+}
+
+// MaxHeightIndicator sets a line to show in place of lines cut off by
+// MaxHeight. If the format has a verb, it's passed to fmt.Sprintf along with
+// the number of lines not shown, for example:
+//
+//	var style = lipgloss.NewStyle().
+//	    MaxHeight(10).
+//	    MaxHeightIndicator("… %d more")
+//
+// The indicator takes the place of the last line that would otherwise be
+// shown, so the result is never taller than MaxHeight.
+func (s Style) MaxHeightIndicator(format string) Style {
+	s.set(maxHeightIndicatorKey, format)
+	return s
+}
+
 // MaxHeight applies a max height to a given style. This is useful in enforcing
 // a certain height at render time, particularly with arbitrary strings and
 // styles.
@@ -531,6 +852,9 @@ func (s Style) TabWidth(n int) Style {
 // UnderlineSpaces determines whether to underline spaces between words. By
 // default, this is true. Spaces can also be underlined without underlining the
 // text itself.
+//
+// Spaces are underlined with the same UnderlineStyle and UnderlineColor as the
+// text.
 func (s Style) UnderlineSpaces(v bool) Style {
 	s.set(underlineSpacesKey, v)
 	return s
@@ -556,6 +880,25 @@ func (s Style) Transform(fn func(string) string) Style {
 	return s
 }
 
+// Hyperlink turns the rendered text into a clickable link in terminals that
+// support OSC 8 hyperlinks. Optional params are key=value pairs, such as
+// "id=docs", which some terminals use to group links spanning several lines.
+//
+// The link is opened and closed on every line of text, so it survives word
+// wrapping, padding and borders. Hyperlinks don't affect the measured width
+// of the output and aren't rendered at all when the color profile is Ascii,
+// which is the case when the output isn't a terminal.
+//
+// Example:
+//
+//	s := lipgloss.NewStyle().
+//	    Underline(true).
+//	    Hyperlink("https://charm.sh")
+func (s Style) Hyperlink(url string, params ...string) Style {
+	s.set(hyperlinkKey, hyperlink{url: url, params: strings.Join(params, ":")})
+	return s
+}
+
 // Renderer sets the renderer for the style. This is useful for changing the
 // renderer for a style that is being used in a different context.
 func (s Style) Renderer(r *Renderer) Style {
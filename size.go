@@ -2,19 +2,21 @@ package lipgloss
 
 import (
 	"strings"
-
-	"github.com/muesli/reflow/ansi"
 )
 
 // Width returns the cell width of characters in the string. ANSI sequences are
 // ignored and characters wider than one cell (such as Chinese characters and
-// emojis) are appropriately measured.
+// emojis) are appropriately measured. Characters are measured as grapheme
+// clusters, so combining marks, flags and emoji sequences joined with zero
+// width joiners are counted the way terminals show them. Characters of
+// ambiguous width are measured according to the default renderer's policy;
+// see SetAmbiguousWidth.
 //
 // You should use this instead of len(string) len([]rune(string) as neither
 // will give you accurate results.
 func Width(str string) (width int) {
 	for _, l := range strings.Split(str, "\n") {
-		w := ansi.PrintableRuneWidth(l)
+		w := printableWidth(nil, l)
 		if w > width {
 			width = w
 		}
@@ -1,12 +1,11 @@
 package lipgloss
 
 import (
+	"fmt"
+	"io"
 	"strings"
 	"unicode"
 
-	"github.com/muesli/reflow/truncate"
-	"github.com/muesli/reflow/wordwrap"
-	"github.com/muesli/reflow/wrap"
 	"github.com/muesli/termenv"
 )
 
@@ -67,6 +66,14 @@ const (
 	borderBottomBackgroundKey
 	borderLeftBackgroundKey
 
+	// Border labels.
+	borderTitleKey
+	borderTitleAlignKey
+	borderTitleStyleKey
+	borderFooterKey
+	borderFooterAlignKey
+	borderFooterStyleKey
+
 	inlineKey
 	maxWidthKey
 	maxHeightKey
@@ -75,10 +82,37 @@ const (
 	strikethroughSpacesKey
 
 	transformKey
+	hyperlinkKey
+
+	underlineStyleKey
+	underlineColorKey
+
+	justifyKey
+	hyphenateKey
+
+	ellipsisKey
+	ellipsisPositionKey
+	maxHeightIndicatorKey
 )
 
-// A set of properties.
-type rules map[propKey]interface{}
+// props is a set of properties, with a bit for each key. It's also used to
+// hold the values of boolean properties.
+type props uint64
+
+// set returns the set with the property added.
+func (p props) set(k propKey) props {
+	return p | 1<<k
+}
+
+// unset returns the set with the property removed.
+func (p props) unset(k propKey) props {
+	return p &^ (1 << k)
+}
+
+// has returns whether the property is in the set.
+func (p props) has(k propKey) bool {
+	return p&(1<<k) != 0
+}
 
 // NewStyle returns a new, empty Style. While it's syntactic sugar for the
 // Style{} primitive, it's recommended to use this function for creating styles
@@ -98,10 +132,71 @@ func (r *Renderer) NewStyle() Style {
 }
 
 // Style contains a set of rules that comprise a style as a whole.
+//
+// Styles are values: setting a rule returns a new style and leaves the
+// original as it was, so styles can be copied by assignment and shared
+// freely.
 type Style struct {
 	r     *Renderer
-	rules map[propKey]interface{}
 	value string
+
+	// props holds which properties are set, and attrs the values of the
+	// boolean ones.
+	props props
+	attrs props
+
+	fgColor TerminalColor
+	bgColor TerminalColor
+
+	width  int
+	height int
+
+	alignHorizontal Position
+	alignVertical   Position
+
+	paddingTop    int
+	paddingRight  int
+	paddingBottom int
+	paddingLeft   int
+
+	marginTop     int
+	marginRight   int
+	marginBottom  int
+	marginLeft    int
+	marginBgColor TerminalColor
+
+	borderStyle         Border
+	borderTopFgColor    TerminalColor
+	borderRightFgColor  TerminalColor
+	borderBottomFgColor TerminalColor
+	borderLeftFgColor   TerminalColor
+	borderTopBgColor    TerminalColor
+	borderRightBgColor  TerminalColor
+	borderBottomBgColor TerminalColor
+	borderLeftBgColor   TerminalColor
+
+	// Label styles are styles themselves, so they're held by pointer. They're
+	// never modified once set.
+	borderTitle       string
+	borderTitleAlign  Position
+	borderTitleStyle  *Style
+	borderFooter      string
+	borderFooterAlign Position
+	borderFooterStyle *Style
+
+	maxWidth  int
+	maxHeight int
+	tabWidth  int
+
+	transform func(string) string
+	link      hyperlink
+
+	underlineStyle UnderlineStyle
+	underlineColor TerminalColor
+
+	ellipsis           string
+	ellipsisPosition   Position
+	maxHeightIndicator string
 }
 
 // joinString joins a list of strings into a single string separated with a
@@ -133,26 +228,24 @@ func (s Style) String() string {
 }
 
 // Copy returns a copy of this style, including any underlying string values.
+// As styles are values, this is the same as assigning the style to another
+// variable.
 func (s Style) Copy() Style {
-	o := NewStyle()
-	o.init()
-	for k, v := range s.rules {
-		o.rules[k] = v
-	}
-	o.r = s.r
-	o.value = s.value
-	return o
+	return s
 }
 
 // Inherit overlays the style in the argument onto this style by copying each explicitly
 // set value from the argument style onto this style if it is not already explicitly set.
 // Existing set values are kept intact and not overwritten.
 //
-// Margins, padding, and underlying string values are not inherited.
+// Margins, padding, border titles and footers, and underlying string values
+// are not inherited.
 func (s Style) Inherit(i Style) Style {
-	s.init()
+	for k := boldKey; k <= maxHeightIndicatorKey; k++ {
+		if !i.isSet(k) {
+			continue
+		}
 
-	for k, v := range i.rules {
 		switch k { //nolint:exhaustive
 		case marginTopKey, marginRightKey, marginBottomKey, marginLeftKey:
 			// Margins are not inherited
@@ -160,23 +253,45 @@ func (s Style) Inherit(i Style) Style {
 		case paddingTopKey, paddingRightKey, paddingBottomKey, paddingLeftKey:
 			// Padding is not inherited
 			continue
+		case borderTitleKey, borderFooterKey:
+			// Border labels are content, so they're not inherited
+			continue
 		case backgroundKey:
 			// The margins also inherit the background color
 			if !s.isSet(marginBackgroundKey) && !i.isSet(marginBackgroundKey) {
-				s.rules[marginBackgroundKey] = v
+				s.marginBgColor = i.bgColor
+				s.props = s.props.set(marginBackgroundKey)
 			}
 		}
 
-		if _, exists := s.rules[k]; exists {
+		if s.isSet(k) {
 			continue
 		}
-		s.rules[k] = v
+		s.setFrom(k, i)
 	}
 	return s
 }
 
 // Render applies the defined style formatting to a given string.
 func (s Style) Render(strs ...string) string {
+	var b strings.Builder
+	_, _ = s.RenderTo(&b, strs...)
+	return b.String()
+}
+
+// RenderTo applies the defined style formatting to a given string, like
+// Render, but writes the result to w a line at a time as it's put together,
+// rather than building it up in memory. This makes it a good fit for large
+// blocks of text, and for writing straight to a terminal, such as a
+// renderer's output:
+//
+//	style.RenderTo(renderer.Output(), logs)
+//
+// The output is exactly the same as Render's. It returns the number of bytes
+// written and any error encountered while writing. Note that a Transform
+// works on the rendered string as a whole, so styles with one set are
+// rendered in full before being written.
+func (s Style) RenderTo(w io.Writer, strs ...string) (int, error) {
 	if s.r == nil {
 		s.r = renderer
 	}
@@ -184,9 +299,24 @@ func (s Style) Render(strs ...string) string {
 		strs = append([]string{s.value}, strs...)
 	}
 
-	var (
-		str = joinString(strs...)
+	str := joinString(strs...)
+	if s.props == 0 {
+		return io.WriteString(w, s.maybeConvertTabs(str))
+	}
+
+	if transform := s.getAsTransform(transformKey); transform != nil {
+		var b strings.Builder
+		_, _ = s.render(&b, str)
+		return io.WriteString(w, transform(b.String()))
+	}
 
+	return s.render(w, str)
+}
+
+// render renders a string with the style's rules, other than Transform,
+// writing it to w.
+func (s Style) render(w io.Writer, str string) (int, error) {
+	var (
 		p            = s.r.ColorProfile()
 		te           = p.String()
 		teSpace      = p.String()
@@ -203,6 +333,14 @@ func (s Style) Render(strs ...string) string {
 		fg = s.getAsColor(foregroundKey)
 		bg = s.getAsColor(backgroundKey)
 
+		// Gradients vary from cell to cell, so they're applied to the block
+		// as a whole once it's been laid out.
+		fgGradient = asGradient(fg)
+		bgGradient = asGradient(bg)
+
+		underlineStyle = s.getAsUnderlineStyle(underlineStyleKey)
+		underlineColor = s.getAsColor(underlineColorKey)
+
 		width           = s.getAsInt(widthKey)
 		height          = s.getAsInt(heightKey)
 		horizontalAlign = s.getAsPosition(alignHorizontalKey)
@@ -217,6 +355,12 @@ func (s Style) Render(strs ...string) string {
 		inline          = s.getAsBool(inlineKey, false)
 		maxWidth        = s.getAsInt(maxWidthKey)
 		maxHeight       = s.getAsInt(maxHeightKey)
+		justify         = s.getAsBool(justifyKey, false)
+		hyphenate       = s.getAsBool(hyphenateKey, false)
+
+		ellipsis           = s.getAsString(ellipsisKey)
+		ellipsisPosition   = s.GetEllipsisPosition()
+		maxHeightIndicator = s.getAsString(maxHeightIndicatorKey)
 
 		underlineSpaces     = underline && s.getAsBool(underlineSpacesKey, true)
 		strikethroughSpaces = strikethrough && s.getAsBool(strikethroughSpacesKey, true)
@@ -228,12 +372,14 @@ func (s Style) Render(strs ...string) string {
 		// Do we need to style spaces separately?
 		useSpaceStyler = underlineSpaces || strikethroughSpaces
 
-		transform = s.getAsTransform(transformKey)
-	)
+		// Hyperlinks only make sense when we're writing to a terminal.
+		link    = s.getAsHyperlink(hyperlinkKey)
+		useLink = link.url != "" && p != termenv.Ascii
 
-	if len(s.rules) == 0 {
-		return s.maybeConvertTabs(str)
-	}
+		// Extended underline styles and colors are sent as extra SGR
+		// parameters ahead of the ones termenv renders.
+		underlineSeq string
+	)
 
 	// Enable support for ANSI on the legacy Windows cmd.exe console. This is a
 	// no-op on non-Windows systems and on Windows runs only once.
@@ -245,7 +391,23 @@ func (s Style) Render(strs ...string) string {
 	if italic {
 		te = te.Italic()
 	}
-	if underline {
+	if underline && p != termenv.Ascii {
+		var colorSeq string
+		if underlineColor != noColor {
+			colorSeq = underlineColorSequence(underlineColor.color(s.r))
+		}
+		if underlineStyle == UnderlineNone {
+			underlineStyle = UnderlineSingle
+		}
+		if underlineStyle > UnderlineSingle || colorSeq != "" {
+			underlineSeq = underlineStyle.sequence()
+			if colorSeq != "" {
+				underlineSeq += ";" + colorSeq
+			}
+		}
+	}
+
+	if underline && underlineSeq == "" {
 		te = te.Underline()
 	}
 	if reverse {
@@ -261,7 +423,7 @@ func (s Style) Render(strs ...string) string {
 		te = te.Faint()
 	}
 
-	if fg != noColor {
+	if fg != noColor && fgGradient == nil {
 		te = te.Foreground(fg.color(s.r))
 		if styleWhitespace {
 			teWhitespace = teWhitespace.Foreground(fg.color(s.r))
@@ -271,7 +433,7 @@ func (s Style) Render(strs ...string) string {
 		}
 	}
 
-	if bg != noColor {
+	if bg != noColor && bgGradient == nil {
 		te = te.Background(bg.color(s.r))
 		if colorWhitespace {
 			teWhitespace = teWhitespace.Background(bg.color(s.r))
@@ -281,14 +443,14 @@ func (s Style) Render(strs ...string) string {
 		}
 	}
 
-	if underline {
+	if underline && underlineSeq == "" {
 		te = te.Underline()
 	}
 	if strikethrough {
 		te = te.CrossOut()
 	}
 
-	if underlineSpaces {
+	if underlineSpaces && underlineSeq == "" {
 		teSpace = teSpace.Underline()
 	}
 	if strikethroughSpaces {
@@ -306,128 +468,112 @@ func (s Style) Render(strs ...string) string {
 	// Word wrap
 	if !inline && width > 0 {
 		wrapAt := width - leftPadding - rightPadding
-		str = wordwrap.String(str, wrapAt)
-		str = wrap.String(str, wrapAt) // force-wrap long strings
+		str = wrapText(s.r, str, wrapAt, wrapOptions{
+			hyphenate: hyphenate,
+			justify:   justify,
+		})
+	}
+
+	// Truncate content that won't fit within MaxHeight here, rather than at
+	// the end, so that we can mark where it was cut without losing the
+	// bottom padding and border.
+	if !inline && maxHeight > 0 && (ellipsis != "" || maxHeightIndicator != "") {
+		wrapAt := 0
+		if width > 0 {
+			wrapAt = width - leftPadding - rightPadding
+		}
+		str = truncateHeight(s.r, str, maxHeight-s.GetVerticalFrameSize(), wrapAt, ellipsis, maxHeightIndicator)
 	}
 
 	// Render core text
+	lines := strings.Split(str, "\n")
 	{
 		var b strings.Builder
 
-		l := strings.Split(str, "\n")
-		for i := range l {
+		for i := range lines {
+			b.Reset()
+
+			// Open and close hyperlinks on each line so that they survive
+			// padding, borders and so on.
+			if useLink && lines[i] != "" {
+				b.WriteString(link.open())
+			}
 			if useSpaceStyler {
 				// Look for spaces and apply a different styler
-				for _, r := range l[i] {
+				for _, r := range lines[i] {
 					if unicode.IsSpace(r) {
-						b.WriteString(teSpace.Styled(string(r)))
+						if underlineSpaces {
+							b.WriteString(styled(teSpace, underlineSeq, string(r)))
+						} else {
+							b.WriteString(teSpace.Styled(string(r)))
+						}
 						continue
 					}
-					b.WriteString(te.Styled(string(r)))
+					b.WriteString(styled(te, underlineSeq, string(r)))
 				}
 			} else {
-				b.WriteString(te.Styled(l[i]))
+				b.WriteString(styled(te, underlineSeq, lines[i]))
 			}
-			if i != len(l)-1 {
-				b.WriteRune('\n')
+			if useLink && lines[i] != "" {
+				b.WriteString(linkReset)
 			}
-		}
-
-		str = b.String()
-	}
 
-	// Padding
-	if !inline {
-		if leftPadding > 0 {
-			var st *termenv.Style
-			if colorWhitespace || styleWhitespace {
-				st = &teWhitespace
-			}
-			str = padLeft(str, leftPadding, st)
+			lines[i] = b.String()
 		}
+	}
 
-		if rightPadding > 0 {
-			var st *termenv.Style
-			if colorWhitespace || styleWhitespace {
-				st = &teWhitespace
-			}
-			str = padRight(str, rightPadding, st)
-		}
+	// From here on, rather than building up the block a step at a time, we
+	// work out its size up front and then put together and write out each
+	// line in turn.
+	block := newTextBlock(s.r, lines)
 
-		if topPadding > 0 {
-			str = strings.Repeat("\n", topPadding) + str
-		}
+	block.whitespace = teWhitespace
+	block.styleWhitespace = colorWhitespace || styleWhitespace
 
-		if bottomPadding > 0 {
-			str += strings.Repeat("\n", bottomPadding)
-		}
+	// Padding
+	if !inline {
+		block.pad(topPadding, rightPadding, bottomPadding, leftPadding)
 	}
 
 	// Height
 	if height > 0 {
-		str = alignTextVertical(str, verticalAlign, height, nil)
+		block.alignVertical(verticalAlign, height)
 	}
 
 	// Set alignment. This will also pad short lines with spaces so that all
 	// lines are the same length, so we run it under a few different conditions
 	// beyond alignment.
-	{
-		numLines := strings.Count(str, "\n")
-
-		if !(numLines == 0 && width == 0) {
-			var st *termenv.Style
-			if colorWhitespace || styleWhitespace {
-				st = &teWhitespace
-			}
-			str = alignTextHorizontal(str, horizontalAlign, width, st)
-		}
-	}
-
-	if !inline {
-		str = s.applyBorder(str)
-		str = s.applyMargins(str, inline)
+	if !(block.height == 1 && width == 0) {
+		block.alignHorizontal(horizontalAlign, width)
 	}
 
-	// Truncate according to MaxWidth
-	if maxWidth > 0 {
-		lines := strings.Split(str, "\n")
+	block.fg, block.bg = fgGradient, bgGradient
 
-		for i := range lines {
-			lines[i] = truncate.String(lines[i], uint(maxWidth))
-		}
-
-		str = strings.Join(lines, "\n")
-	}
-
-	// Truncate according to MaxHeight
-	if maxHeight > 0 {
-		lines := strings.Split(str, "\n")
-		str = strings.Join(lines[:min(maxHeight, len(lines))], "\n")
+	out := &blockWriter{
+		w:                w,
+		r:                s.r,
+		maxWidth:         maxWidth,
+		maxHeight:        maxHeight,
+		ellipsis:         ellipsis,
+		ellipsisPosition: ellipsisPosition,
 	}
-
-	if transform != nil {
-		return transform(str)
+	if inline {
+		for y := 0; y < block.height; y++ {
+			if y > 0 {
+				out.WriteString("\n")
+			}
+			out.WriteString(block.line(y))
+		}
+	} else {
+		s.writeFramed(out, &block)
 	}
+	out.close()
 
-	return str
+	return out.n, out.err
 }
 
-func (s Style) maybeConvertTabs(str string) string {
-	tw := tabWidthDefault
-	if s.isSet(tabWidthKey) {
-		tw = s.getAsInt(tabWidthKey)
-	}
-	switch tw {
-	case -1:
-		return str
-	case 0:
-		return strings.ReplaceAll(str, "\t", "")
-	default:
-		return strings.ReplaceAll(str, "\t", strings.Repeat(" ", tw))
-	}
-}
-
-func (s Style) applyMargins(str string, inline bool) string {
+// writeFramed writes a block of text with the style's border and margins.
+func (s Style) writeFramed(out *blockWriter, block *textBlock) {
 	var (
 		topMargin    = s.getAsInt(marginTopKey)
 		rightMargin  = s.getAsInt(marginRightKey)
@@ -442,74 +588,159 @@ func (s Style) applyMargins(str string, inline bool) string {
 		styler = styler.Background(bgc.color(s.r))
 	}
 
-	// Add left and right margin
-	str = padLeft(str, leftMargin, &styler)
-	str = padRight(str, rightMargin, &styler)
-
-	// Top/bottom margin
-	if !inline {
-		_, width := getLines(str)
-		spaces := strings.Repeat(" ", width)
+	frame, hasBorder := s.borderFrame(block.width, block.height)
 
-		if topMargin > 0 {
-			str = styler.Styled(strings.Repeat(spaces+"\n", topMargin)) + str
+	// The lines of the bordered block, put together as they're written.
+	lines, width := block.height, block.width
+	if hasBorder {
+		width = frame.width(block.width)
+		if frame.hasTop {
+			lines++
 		}
-		if bottomMargin > 0 {
-			str += styler.Styled(strings.Repeat("\n"+spaces, bottomMargin))
+		if frame.hasBottom {
+			lines++
 		}
 	}
-
-	return str
-}
-
-// Apply left padding.
-func padLeft(str string, n int, style *termenv.Style) string {
-	if n == 0 {
-		return str
+	line := func(y int) string {
+		if !hasBorder {
+			return block.line(y)
+		}
+		if frame.hasTop {
+			if y == 0 {
+				return frame.top
+			}
+			y--
+		}
+		if y == block.height {
+			return frame.bottom
+		}
+		return frame.side(y, block.line(y))
 	}
 
-	sp := strings.Repeat(" ", n)
-	if style != nil {
-		sp = style.Styled(sp)
+	// Empty blocks don't get a right margin, so we need to check for one
+	// before writing anything.
+	var first string
+	if lines == 1 {
+		first = line(0)
+		if first == "" && leftMargin == 0 {
+			rightMargin = 0
+		}
 	}
+	width += leftMargin + rightMargin
 
-	b := strings.Builder{}
-	l := strings.Split(str, "\n")
+	var left, right string
+	if leftMargin > 0 {
+		left = styler.Styled(strings.Repeat(" ", leftMargin))
+	}
+	if rightMargin > 0 {
+		right = styler.Styled(strings.Repeat(" ", rightMargin))
+	}
+	spaces := strings.Repeat(" ", width)
 
-	for i := range l {
-		b.WriteString(sp)
-		b.WriteString(l[i])
-		if i != len(l)-1 {
-			b.WriteRune('\n')
+	if topMargin > 0 {
+		out.WriteString(styler.Styled(strings.Repeat(spaces+"\n", topMargin)))
+	}
+	for y := 0; y < lines; y++ {
+		if y > 0 {
+			out.WriteString("\n")
 		}
+		out.WriteString(left)
+		if lines == 1 {
+			out.WriteString(first)
+		} else {
+			out.WriteString(line(y))
+		}
+		out.WriteString(right)
+	}
+	if bottomMargin > 0 {
+		out.WriteString(styler.Styled(strings.Repeat("\n"+spaces, bottomMargin)))
 	}
+}
 
-	return b.String()
+func (s Style) maybeConvertTabs(str string) string {
+	tw := tabWidthDefault
+	if s.isSet(tabWidthKey) {
+		tw = s.getAsInt(tabWidthKey)
+	}
+	return convertTabs(str, tw)
 }
 
-// Apply right padding.
-func padRight(str string, n int, style *termenv.Style) string {
-	if n == 0 || str == "" {
+// convertTabs replaces the tabs in str with tw spaces, removes them if tw is
+// 0, and leaves them alone if it's NoTabConversion.
+func convertTabs(str string, tw int) string {
+	switch tw {
+	case -1:
+		return str
+	case 0:
+		return strings.ReplaceAll(str, "\t", "")
+	default:
+		return strings.ReplaceAll(str, "\t", strings.Repeat(" ", tw))
+	}
+}
+
+// truncateHeight cuts str down to the given number of lines, marking the cut
+// with either an indicator line, which is formatted with the number of lines
+// cut, or an ellipsis at the end of the last line kept. The last line is kept
+// within width, unless width is 0.
+func truncateHeight(r *Renderer, str string, height, width int, ellipsis, indicator string) string {
+	lines := isolateLines(str)
+	if height < 1 || len(lines) <= height {
 		return str
 	}
 
-	sp := strings.Repeat(" ", n)
-	if style != nil {
-		sp = style.Styled(sp)
+	last := height - 1
+	if indicator != "" {
+		lines[last] = formatIndicator(indicator, len(lines)-last)
+		if width > 0 {
+			lines[last] = truncateLine(r, lines[last], width, ellipsis, Right)
+		}
+	} else {
+		l := strings.TrimRight(lines[last], " ")
+		if width > 0 {
+			l = truncateLine(r, l, max(0, width-printableWidth(r, ellipsis)), "", Right)
+		}
+		lines[last] = l + ellipsis
 	}
 
-	b := strings.Builder{}
-	l := strings.Split(str, "\n")
+	return strings.Join(lines[:height], "\n")
+}
+
+// formatIndicator formats a max height indicator with the number of lines
+// cut. Only the first verb for an integer, such as %d, is formatted; any other
+// % is shown as it is, so indicators without a verb are left alone.
+func formatIndicator(indicator string, n int) string {
+	var (
+		b    strings.Builder
+		verb bool
+	)
+	for i := 0; i < len(indicator); i++ {
+		if indicator[i] != '%' {
+			b.WriteByte(indicator[i])
+			continue
+		}
 
-	for i := range l {
-		b.WriteString(l[i])
-		b.WriteString(sp)
-		if i != len(l)-1 {
-			b.WriteRune('\n')
+		// Skip past the flags, width and precision to the verb.
+		j := i + 1
+		for j < len(indicator) && strings.IndexByte("+-# 0123456789.", indicator[j]) >= 0 {
+			j++
+		}
+		switch {
+		case j == i+1 && j < len(indicator) && indicator[j] == '%':
+			b.WriteString("%%")
+			i = j
+		case !verb && j < len(indicator) && strings.IndexByte("bcdoOqvxXU", indicator[j]) >= 0:
+			b.WriteString(indicator[i : j+1])
+			i = j
+			verb = true
+		default:
+			b.WriteString("%%")
 		}
 	}
 
-	return b.String()
+	if !verb {
+		return strings.ReplaceAll(b.String(), "%%", "%")
+	}
+	return fmt.Sprintf(b.String(), n)
 }
 
 func max(a, b int) int {
@@ -1,6 +1,7 @@
 package lipgloss
 
 import (
+	"errors"
 	"io"
 	"reflect"
 	"strings"
@@ -60,6 +61,77 @@ func TestStyleRender(t *testing.T) {
 	}
 }
 
+func TestStyleRenderTo(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.TrueColor)
+	t.Parallel()
+
+	tt := []Style{
+		r.NewStyle(),
+		r.NewStyle().Bold(true),
+		r.NewStyle().Padding(1, 2).Margin(1).MarginBackground(Color("#1c1c1c")),
+		r.NewStyle().Border(RoundedBorder()).BorderTitle("Title").Width(12).Align(Center),
+		r.NewStyle().Background(HorizontalGradient(Color("#ff0000"), Color("#0000ff"))).Height(4),
+		r.NewStyle().MaxWidth(6).MaxHeight(2).Ellipsis("…"),
+		r.NewStyle().Inline(true).Underline(true),
+		r.NewStyle().Transform(strings.ToUpper).Padding(0, 1),
+	}
+
+	const str = "Hello, world!\nThis is\ta test."
+
+	for i, s := range tt {
+		var w countingWriter
+		n, err := s.RenderTo(&w, str)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i, err)
+		}
+
+		expected := s.Render(str)
+		if res := w.String(); res != expected {
+			t.Errorf("Test %d, expected:\n\n`%s`\n`%s`\n\nActual output:\n\n`%s`\n`%s`\n\n",
+				i, expected, formatEscapes(expected), res, formatEscapes(res))
+		}
+		if n != len(expected) {
+			t.Errorf("Test %d, expected %d bytes written, got %d", i, len(expected), n)
+		}
+	}
+
+	// Blocks are written a line at a time.
+	var w countingWriter
+	_, _ = r.NewStyle().Border(NormalBorder()).Padding(1).RenderTo(&w, "a\nb\nc")
+	if w.writes < 7 {
+		t.Errorf("Expected the block to be written in pieces, got %d writes", w.writes)
+	}
+
+	// Writing stops at the first error.
+	_, err := r.NewStyle().Border(NormalBorder()).RenderTo(errWriter{}, "a\nb")
+	if err != errWrite {
+		t.Errorf("Expected a write error, got %v", err)
+	}
+}
+
+// countingWriter is a strings.Builder that counts writes to it.
+type countingWriter struct {
+	strings.Builder
+	writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.Builder.Write(p)
+}
+
+func (w *countingWriter) WriteString(s string) (int, error) {
+	w.writes++
+	return w.Builder.WriteString(s)
+}
+
+var errWrite = errors.New("write failed")
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) { return 0, errWrite }
+
 func TestStyleCustomRender(t *testing.T) {
 	r := NewRenderer(io.Discard)
 	r.SetHasDarkBackground(false)
@@ -213,114 +285,114 @@ func TestStyleUnset(t *testing.T) {
 
 	s := NewStyle().Bold(true)
 	requireTrue(t, s.GetBold())
-	s.UnsetBold()
+	s = s.UnsetBold()
 	requireFalse(t, s.GetBold())
 
 	s = NewStyle().Italic(true)
 	requireTrue(t, s.GetItalic())
-	s.UnsetItalic()
+	s = s.UnsetItalic()
 	requireFalse(t, s.GetItalic())
 
 	s = NewStyle().Underline(true)
 	requireTrue(t, s.GetUnderline())
-	s.UnsetUnderline()
+	s = s.UnsetUnderline()
 	requireFalse(t, s.GetUnderline())
 
 	s = NewStyle().Strikethrough(true)
 	requireTrue(t, s.GetStrikethrough())
-	s.UnsetStrikethrough()
+	s = s.UnsetStrikethrough()
 	requireFalse(t, s.GetStrikethrough())
 
 	s = NewStyle().Reverse(true)
 	requireTrue(t, s.GetReverse())
-	s.UnsetReverse()
+	s = s.UnsetReverse()
 	requireFalse(t, s.GetReverse())
 
 	s = NewStyle().Blink(true)
 	requireTrue(t, s.GetBlink())
-	s.UnsetBlink()
+	s = s.UnsetBlink()
 	requireFalse(t, s.GetBlink())
 
 	s = NewStyle().Faint(true)
 	requireTrue(t, s.GetFaint())
-	s.UnsetFaint()
+	s = s.UnsetFaint()
 	requireFalse(t, s.GetFaint())
 
 	s = NewStyle().Inline(true)
 	requireTrue(t, s.GetInline())
-	s.UnsetInline()
+	s = s.UnsetInline()
 	requireFalse(t, s.GetInline())
 
 	// colors
 	col := Color("#ffffff")
 	s = NewStyle().Foreground(col)
 	requireEqual(t, col, s.GetForeground())
-	s.UnsetForeground()
+	s = s.UnsetForeground()
 	requireNotEqual(t, col, s.GetForeground())
 
 	s = NewStyle().Background(col)
 	requireEqual(t, col, s.GetBackground())
-	s.UnsetBackground()
+	s = s.UnsetBackground()
 	requireNotEqual(t, col, s.GetBackground())
 
 	// margins
 	s = NewStyle().Margin(1, 2, 3, 4)
 	requireEqual(t, 1, s.GetMarginTop())
-	s.UnsetMarginTop()
+	s = s.UnsetMarginTop()
 	requireEqual(t, 0, s.GetMarginTop())
 
 	requireEqual(t, 2, s.GetMarginRight())
-	s.UnsetMarginRight()
+	s = s.UnsetMarginRight()
 	requireEqual(t, 0, s.GetMarginRight())
 
 	requireEqual(t, 3, s.GetMarginBottom())
-	s.UnsetMarginBottom()
+	s = s.UnsetMarginBottom()
 	requireEqual(t, 0, s.GetMarginBottom())
 
 	requireEqual(t, 4, s.GetMarginLeft())
-	s.UnsetMarginLeft()
+	s = s.UnsetMarginLeft()
 	requireEqual(t, 0, s.GetMarginLeft())
 
 	// padding
 	s = NewStyle().Padding(1, 2, 3, 4)
 	requireEqual(t, 1, s.GetPaddingTop())
-	s.UnsetPaddingTop()
+	s = s.UnsetPaddingTop()
 	requireEqual(t, 0, s.GetPaddingTop())
 
 	requireEqual(t, 2, s.GetPaddingRight())
-	s.UnsetPaddingRight()
+	s = s.UnsetPaddingRight()
 	requireEqual(t, 0, s.GetPaddingRight())
 
 	requireEqual(t, 3, s.GetPaddingBottom())
-	s.UnsetPaddingBottom()
+	s = s.UnsetPaddingBottom()
 	requireEqual(t, 0, s.GetPaddingBottom())
 
 	requireEqual(t, 4, s.GetPaddingLeft())
-	s.UnsetPaddingLeft()
+	s = s.UnsetPaddingLeft()
 	requireEqual(t, 0, s.GetPaddingLeft())
 
 	// border
 	s = NewStyle().Border(normalBorder, true, true, true, true)
 	requireTrue(t, s.GetBorderTop())
-	s.UnsetBorderTop()
+	s = s.UnsetBorderTop()
 	requireFalse(t, s.GetBorderTop())
 
 	requireTrue(t, s.GetBorderRight())
-	s.UnsetBorderRight()
+	s = s.UnsetBorderRight()
 	requireFalse(t, s.GetBorderRight())
 
 	requireTrue(t, s.GetBorderBottom())
-	s.UnsetBorderBottom()
+	s = s.UnsetBorderBottom()
 	requireFalse(t, s.GetBorderBottom())
 
 	requireTrue(t, s.GetBorderLeft())
-	s.UnsetBorderLeft()
+	s = s.UnsetBorderLeft()
 	requireFalse(t, s.GetBorderLeft())
 
 	// tab width
 	s = NewStyle().TabWidth(2)
 	requireEqual(t, s.GetTabWidth(), 2)
-	s.UnsetTabWidth()
+	s = s.UnsetTabWidth()
 	requireNotEqual(t, s.GetTabWidth(), 4)
 }
 
@@ -411,6 +483,201 @@ func TestStringTransform(t *testing.T) {
 	}
 }
 
+func TestHyperlink(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.TrueColor)
+
+	const (
+		open  = "\x1b]8;id=1;https://charm.sh\x1b\\"
+		close = "\x1b]8;;\x1b\\"
+	)
+
+	s := r.NewStyle().
+		Hyperlink("https://charm.sh", "id=1").
+		Width(5).
+		Border(NormalBorder())
+
+	expected := strings.Join([]string{
+		"┌─────┐",
+		"│" + open + "hello" + close + "│",
+		"│" + open + "world" + close + "│",
+		"└─────┘",
+	}, "\n")
+
+	res := s.Render("hello world")
+	if res != expected {
+		t.Errorf("Expected:\n\n`%s`\n\nActual output:\n\n`%s`\n\n",
+			formatEscapes(expected), formatEscapes(res))
+	}
+	requireEqual(t, 7, Width(res))
+
+	url, params := s.GetHyperlink()
+	requireEqual(t, "https://charm.sh", url)
+	requireEqual(t, "id=1", params)
+
+	// Hyperlinks degrade to plain text when not rendering to a terminal.
+	r.SetColorProfile(termenv.Ascii)
+	requireEqual(t, "hello", r.NewStyle().Hyperlink("https://charm.sh").Render("hello"))
+}
+
+func TestUnderlineStyle(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.TrueColor)
+
+	tt := []struct {
+		name     string
+		style    Style
+		expected string
+	}{
+		{
+			name:     "curly",
+			style:    r.NewStyle().UnderlineStyle(UnderlineCurly).UnderlineSpaces(false),
+			expected: "\x1b[4:3mhello\x1b[0m",
+		},
+		{
+			name:     "curly with color",
+			style:    r.NewStyle().UnderlineStyle(UnderlineCurly).UnderlineColor(Color("#ff0000")).UnderlineSpaces(false),
+			expected: "\x1b[4:3;58;2;255;0;0mhello\x1b[0m",
+		},
+		{
+			name:     "single with color",
+			style:    r.NewStyle().Underline(true).UnderlineColor(Color("9")).Bold(true).UnderlineSpaces(false),
+			expected: "\x1b[4;58;5;9m\x1b[1mhello\x1b[0m",
+		},
+		{
+			name:     "color without underline",
+			style:    r.NewStyle().UnderlineColor(Color("9")),
+			expected: "hello",
+		},
+		{
+			name:     "none",
+			style:    r.NewStyle().Underline(true).UnderlineStyle(UnderlineNone),
+			expected: "hello",
+		},
+	}
+
+	for i, tc := range tt {
+		res := tc.style.Render("hello")
+		if res != tc.expected {
+			t.Errorf("Test %d (%s), expected:\n\n`%s`\n\nActual output:\n\n`%s`\n\n",
+				i, tc.name, formatEscapes(tc.expected), formatEscapes(res))
+		}
+	}
+
+	// Spaces are underlined in the same style as the text.
+	res := r.NewStyle().UnderlineStyle(UnderlineDashed).Render("a b")
+	requireEqual(t, "\x1b[4:5ma\x1b[0m\x1b[4:5m \x1b[0m\x1b[4:5mb\x1b[0m", res)
+
+	// Extended underlines are dropped along with everything else when not
+	// rendering to a terminal.
+	r.SetColorProfile(termenv.Ascii)
+	requireEqual(t, "hello", r.NewStyle().UnderlineStyle(UnderlineCurly).Render("hello"))
+
+	// The underline color degrades with the color profile.
+	r.SetColorProfile(termenv.ANSI256)
+	res = r.NewStyle().
+		UnderlineStyle(UnderlineDouble).
+		UnderlineColor(Color("#ff0000")).
+		UnderlineSpaces(false).
+		Render("hello")
+	requireEqual(t, "\x1b[4:2;58;5;196mhello\x1b[0m", res)
+
+	s := NewStyle().UnderlineStyle(UnderlineDotted)
+	requireTrue(t, s.GetUnderline())
+	requireEqual(t, UnderlineDotted, s.GetUnderlineStyle())
+	requireEqual(t, UnderlineSingle, NewStyle().Underline(true).GetUnderlineStyle())
+	requireEqual(t, UnderlineNone, s.Underline(false).GetUnderlineStyle())
+}
+
+func TestEllipsis(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.Ascii)
+
+	tt := []struct {
+		name     string
+		style    Style
+		input    string
+		expected string
+	}{
+		{
+			name:     "max width",
+			style:    r.NewStyle().MaxWidth(6).Ellipsis("…"),
+			input:    "hello world",
+			expected: "hello…",
+		},
+		{
+			name:     "max width start",
+			style:    r.NewStyle().MaxWidth(8).Ellipsis("…").EllipsisPosition(Left),
+			input:    "src/main.go",
+			expected: "…main.go",
+		},
+		{
+			name:     "max width narrower than ellipsis",
+			style:    r.NewStyle().MaxWidth(1).Ellipsis("..."),
+			input:    "hello",
+			expected: ".",
+		},
+		{
+			name:     "max height",
+			style:    r.NewStyle().Width(5).MaxHeight(4).Ellipsis("…").Border(NormalBorder()),
+			input:    "one two three four",
+			expected: "┌─────┐\n│one  │\n│two… │\n└─────┘",
+		},
+		{
+			name:     "max height indicator",
+			style:    r.NewStyle().MaxHeight(3).MaxHeightIndicator("… %d more"),
+			input:    "a\nb\nc\nd\ne\nf",
+			expected: "a       \nb       \n… 4 more",
+		},
+		{
+			name:     "max height indicator wider than width",
+			style:    r.NewStyle().Width(5).MaxHeight(2).MaxHeightIndicator("… %d more").Ellipsis("…"),
+			input:    "abc\ndef\nghi",
+			expected: "abc  \n… 2 …",
+		},
+		{
+			name:     "max height indicator without verb",
+			style:    r.NewStyle().MaxHeight(2).MaxHeightIndicator("…"),
+			input:    "a\nb\nc",
+			expected: "a\n…",
+		},
+		{
+			name:     "max height indicator with percent",
+			style:    r.NewStyle().MaxHeight(2).MaxHeightIndicator("100%% more"),
+			input:    "a\nb\nc",
+			expected: "a        \n100% more",
+		},
+		{
+			name:     "max height indicator ending in percent",
+			style:    r.NewStyle().MaxHeight(2).MaxHeightIndicator("100%"),
+			input:    "a\nb\nc",
+			expected: "a   \n100%",
+		},
+		{
+			name:     "max height indicator with stray percent",
+			style:    r.NewStyle().MaxHeight(2).MaxHeightIndicator("%d more (50% cut) %d"),
+			input:    "a\nb\nc",
+			expected: "a                  \n2 more (50% cut) %d",
+		},
+		{
+			name:     "no ellipsis",
+			style:    r.NewStyle().MaxHeight(2),
+			input:    "a\nb\nc",
+			expected: "a\nb",
+		},
+	}
+
+	for i, tc := range tt {
+		res := tc.style.Render(tc.input)
+		if res != tc.expected {
+			t.Errorf("Test %d (%s), expected:\n\n`%s`\n\nActual output:\n\n`%s`\n\n",
+				i, tc.name, tc.expected, res)
+		}
+	}
+
+	requireEqual(t, Right, NewStyle().GetEllipsisPosition())
+}
+
 func BenchmarkStyleRender(b *testing.B) {
 	s := NewStyle().
 		Bold(true).
@@ -421,6 +688,47 @@ func BenchmarkStyleRender(b *testing.B) {
 	}
 }
 
+func BenchmarkStyleRenderBlock(b *testing.B) {
+	s := NewStyle().
+		Bold(true).
+		Foreground(Color("#ffffff")).
+		Background(Color("#7d56f4")).
+		Padding(1, 2).
+		Width(20).
+		Align(Center).
+		Border(RoundedBorder())
+
+	for i := 0; i < b.N; i++ {
+		s.Render("Hello world")
+	}
+}
+
+func BenchmarkStyleCopy(b *testing.B) {
+	s := NewStyle().
+		Bold(true).
+		Foreground(Color("#ffffff")).
+		Padding(0, 1).
+		Border(NormalBorder())
+
+	for i := 0; i < b.N; i++ {
+		_ = s.Copy().Italic(true)
+	}
+}
+
+func BenchmarkStyleInherit(b *testing.B) {
+	parent := NewStyle().
+		Bold(true).
+		Foreground(Color("#ffffff")).
+		Background(Color("#7d56f4")).
+		Padding(0, 1).
+		Border(NormalBorder())
+	s := NewStyle().Italic(true).Foreground(Color("#000000"))
+
+	for i := 0; i < b.N; i++ {
+		_ = s.Inherit(parent)
+	}
+}
+
 func requireTrue(tb testing.TB, b bool) {
 	requireEqual(tb, true, b)
 }
@@ -0,0 +1,963 @@
+package lipgloss
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// StyleSheet is a set of named styles loaded from a CSS-like style sheet, so
+// that styles can be customized without recompiling:
+//
+//	/* Styles for the help view. */
+//	base {
+//	    foreground: #FAFAFA;
+//	    background: adaptive(#F5F5F5, #1C1C1C);
+//	}
+//
+//	title {
+//	    inherit: base;
+//	    bold: true;
+//	    padding: 1 2;
+//	    border: rounded;
+//	    border-foreground: theme(primary);
+//	}
+//
+//	error, warning {
+//	    foreground: #FF5F5F;
+//	}
+//
+// Each block sets properties on the styles named before it. Properties are
+// named after the Style methods that set them, in kebab-case, and take the
+// same values, separated by spaces: true or false for booleans, whole
+// numbers for sizes, left, center, right, top, bottom or a number for
+// positions, where 0 is left or top and 1 is right or bottom, and quoted
+// strings for text.
+//
+// Colors are hex values, ANSI color numbers, none, or quoted strings for
+// anything else Color accepts, or one of the following functions:
+//
+//	ansi(n)                                    // ANSIColor
+//	adaptive(light, dark)                      // AdaptiveColor
+//	complete(truecolor, ansi256, ansi)         // CompleteColor
+//	adaptive(complete(...), complete(...))     // CompleteAdaptiveColor
+//	theme(name)                                // ThemeColor
+//	gradient(color, color...)                  // HorizontalGradient
+//	vertical-gradient(color, color...)         // VerticalGradient
+//	lighten(color, amount)                     // Lighten
+//	darken(color, amount)                      // Darken
+//	blend(color, color, t)                     // Blend
+//	complementary(color)                       // Complementary
+//	alpha(color, background, alpha)            // Alpha
+//	ensure-contrast(color, background, ratio)  // EnsureContrast
+//
+// Borders are named normal, rounded, block, outer-half-block,
+// inner-half-block, thick, double, hidden or none, or given as
+// border("─", "─", "│", "│", "╭", ...) with their parts in the order of the
+// fields of Border. The border property may be followed by which sides to
+// draw, as with Style.Border.
+//
+// The inherit property takes the names of other styles in the sheet, which
+// are inherited from in order with Style.Inherit. Comments are written /* like
+// this */ or // like this.
+type StyleSheet struct {
+	styles map[string]Style
+}
+
+// ParseStyleSheet parses a style sheet, creating its styles with the default
+// renderer. Errors are of type *StyleSheetError and report where in the
+// source the problem is.
+func ParseStyleSheet(src string) (*StyleSheet, error) {
+	return renderer.ParseStyleSheet(src)
+}
+
+// ParseStyleSheet parses a style sheet, creating its styles with the
+// renderer.
+func (r *Renderer) ParseStyleSheet(src string) (*StyleSheet, error) {
+	p := sheetParser{scanner: sheetScanner{src: []rune(src), line: 1, col: 1}}
+	rules, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	b := sheetBuilder{
+		r:        r,
+		styles:   make(map[string]Style),
+		inherits: make(map[string][]sheetValue),
+		state:    make(map[string]int),
+	}
+	for _, rule := range rules {
+		if err := b.apply(rule); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range b.names() {
+		if err := b.resolve(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return &StyleSheet{styles: b.styles}, nil
+}
+
+// Style returns the named style. If there's no such style, a new, empty
+// style is returned, so that a style sheet can leave out styles it doesn't
+// care about.
+func (s *StyleSheet) Style(name string) Style {
+	st, ok := s.Lookup(name)
+	if !ok {
+		return NewStyle()
+	}
+	return st
+}
+
+// Lookup returns the named style and whether the style sheet defines it.
+func (s *StyleSheet) Lookup(name string) (Style, bool) {
+	st, ok := s.styles[name]
+	return st, ok
+}
+
+// Names returns the names of the styles in the style sheet, sorted.
+func (s *StyleSheet) Names() []string {
+	names := make([]string, 0, len(s.styles))
+	for name := range s.styles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// StyleSheetError is an error in a style sheet, along with where it is.
+// Lines and columns start at 1.
+type StyleSheetError struct {
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *StyleSheetError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Msg)
+}
+
+// sheetToken is a single token in a style sheet.
+type sheetToken struct {
+	kind      sheetTokenKind
+	text      string
+	line, col int
+}
+
+type sheetTokenKind int
+
+const (
+	sheetEOF sheetTokenKind = iota
+	sheetWord
+	sheetString
+	sheetPunct
+)
+
+func (t sheetToken) String() string {
+	switch t.kind {
+	case sheetEOF:
+		return "end of file"
+	case sheetString:
+		return strconv.Quote(t.text)
+	default:
+		return "'" + t.text + "'"
+	}
+}
+
+func (t sheetToken) is(punct string) bool {
+	return t.kind == sheetPunct && t.text == punct
+}
+
+func (t sheetToken) errorf(format string, args ...interface{}) error {
+	return &StyleSheetError{Line: t.line, Column: t.col, Msg: fmt.Sprintf(format, args...)}
+}
+
+// sheetScanner splits a style sheet into tokens.
+type sheetScanner struct {
+	src       []rune
+	pos       int
+	line, col int
+}
+
+func (s *sheetScanner) peekRune(offset int) rune {
+	if s.pos+offset >= len(s.src) {
+		return 0
+	}
+	return s.src[s.pos+offset]
+}
+
+func (s *sheetScanner) advance() rune {
+	r := s.src[s.pos]
+	s.pos++
+	if r == '\n' {
+		s.line++
+		s.col = 1
+	} else {
+		s.col++
+	}
+	return r
+}
+
+// skip skips whitespace and comments.
+func (s *sheetScanner) skip() error {
+	for s.pos < len(s.src) {
+		switch r := s.peekRune(0); {
+		case unicode.IsSpace(r):
+			s.advance()
+		case r == '/' && s.peekRune(1) == '/':
+			for s.pos < len(s.src) && s.peekRune(0) != '\n' {
+				s.advance()
+			}
+		case r == '/' && s.peekRune(1) == '*':
+			line, col := s.line, s.col
+			s.advance()
+			s.advance()
+			for !(s.peekRune(0) == '*' && s.peekRune(1) == '/') {
+				if s.pos >= len(s.src) {
+					return &StyleSheetError{Line: line, Column: col, Msg: "unterminated comment"}
+				}
+				s.advance()
+			}
+			s.advance()
+			s.advance()
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func (s *sheetScanner) next() (sheetToken, error) {
+	if err := s.skip(); err != nil {
+		return sheetToken{}, err
+	}
+
+	t := sheetToken{line: s.line, col: s.col}
+	if s.pos >= len(s.src) {
+		return t, nil
+	}
+
+	switch r := s.peekRune(0); {
+	case strings.ContainsRune("{};:,()", r):
+		t.kind = sheetPunct
+		t.text = string(s.advance())
+	case r == '"':
+		t.kind = sheetString
+		s.advance()
+		var b strings.Builder
+		for {
+			if s.pos >= len(s.src) || s.peekRune(0) == '\n' {
+				return t, t.errorf("unterminated string")
+			}
+			c := s.advance()
+			if c == '"' {
+				break
+			}
+			if c == '\\' && s.pos < len(s.src) {
+				switch e := s.advance(); e {
+				case 'n':
+					c = '\n'
+				case 't':
+					c = '\t'
+				default:
+					c = e
+				}
+			}
+			b.WriteRune(c)
+		}
+		t.text = b.String()
+	default:
+		t.kind = sheetWord
+		start := s.pos
+		for s.pos < len(s.src) {
+			c := s.peekRune(0)
+			if unicode.IsSpace(c) || strings.ContainsRune("{};:,()\"", c) {
+				break
+			}
+			if c == '/' && (s.peekRune(1) == '/' || s.peekRune(1) == '*') {
+				break
+			}
+			s.advance()
+		}
+		t.text = string(s.src[start:s.pos])
+	}
+
+	return t, nil
+}
+
+// sheetValue is a single value of a property: a word, a string or a function
+// call.
+type sheetValue struct {
+	sheetToken
+	args []sheetValue
+	call bool
+}
+
+// sheetDecl is a property declaration.
+type sheetDecl struct {
+	name   sheetToken
+	values []sheetValue
+}
+
+// sheetRule is a block of declarations along with the names of the styles
+// it applies to.
+type sheetRule struct {
+	names []sheetToken
+	decls []sheetDecl
+}
+
+// sheetParser parses a style sheet into rules.
+type sheetParser struct {
+	scanner sheetScanner
+	tok     sheetToken
+	peeked  bool
+}
+
+func (p *sheetParser) peek() (sheetToken, error) {
+	if !p.peeked {
+		t, err := p.scanner.next()
+		if err != nil {
+			return t, err
+		}
+		p.tok, p.peeked = t, true
+	}
+	return p.tok, nil
+}
+
+func (p *sheetParser) next() (sheetToken, error) {
+	t, err := p.peek()
+	p.peeked = false
+	return t, err
+}
+
+// expect reads the next token, which must be the given punctuation.
+func (p *sheetParser) expect(punct string) (sheetToken, error) {
+	t, err := p.next()
+	if err != nil {
+		return t, err
+	}
+	if !t.is(punct) {
+		return t, t.errorf("expected '%s', found %s", punct, t)
+	}
+	return t, nil
+}
+
+func (p *sheetParser) parse() ([]sheetRule, error) {
+	var rules []sheetRule
+	for {
+		t, err := p.peek()
+		if err != nil {
+			return nil, err
+		}
+		if t.kind == sheetEOF {
+			return rules, nil
+		}
+		rule, err := p.parseRule()
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+}
+
+// parseRule parses a rule of the form: name, name { property: value; ... }
+func (p *sheetParser) parseRule() (sheetRule, error) {
+	var rule sheetRule
+
+	for {
+		t, err := p.next()
+		if err != nil {
+			return rule, err
+		}
+		if t.kind != sheetWord {
+			return rule, t.errorf("expected a style name, found %s", t)
+		}
+		rule.names = append(rule.names, t)
+
+		if t, err = p.next(); err != nil {
+			return rule, err
+		}
+		if t.is("{") {
+			break
+		}
+		if !t.is(",") {
+			return rule, t.errorf("expected ',' or '{', found %s", t)
+		}
+	}
+
+	var err error
+	rule.decls, err = p.parseDecls(true)
+	return rule, err
+}
+
+// parseDecls parses property declarations up to the end of a block, or the
+// end of the source if they're not in a block.
+func (p *sheetParser) parseDecls(block bool) ([]sheetDecl, error) {
+	var decls []sheetDecl
+	for {
+		t, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case block && t.is("}"), !block && t.kind == sheetEOF:
+			return decls, nil
+		case t.is(";"):
+			continue
+		case t.kind != sheetWord:
+			return nil, t.errorf("expected a property name, found %s", t)
+		}
+
+		if _, err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		decl := sheetDecl{name: t}
+		if decl.values, err = p.parseValues(); err != nil {
+			return nil, err
+		}
+		if len(decl.values) == 0 {
+			return nil, t.errorf("missing value for %s", t)
+		}
+		decls = append(decls, decl)
+	}
+}
+
+// parseValues parses the values of a property, up to the end of the
+// declaration.
+func (p *sheetParser) parseValues() ([]sheetValue, error) {
+	var values []sheetValue
+	for {
+		t, err := p.peek()
+		if err != nil {
+			return nil, err
+		}
+		if t.is(";") || t.is("}") || t.kind == sheetEOF {
+			if t.is(";") {
+				p.peeked = false
+			}
+			return values, nil
+		}
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+}
+
+// parseValue parses a single value, which may be a function call.
+func (p *sheetParser) parseValue() (sheetValue, error) {
+	t, err := p.next()
+	if err != nil {
+		return sheetValue{}, err
+	}
+	if t.kind != sheetWord && t.kind != sheetString {
+		return sheetValue{}, t.errorf("unexpected %s", t)
+	}
+	v := sheetValue{sheetToken: t}
+
+	// Function calls must have their parenthesis right after the name.
+	next, err := p.peek()
+	if err != nil {
+		return v, err
+	}
+	if t.kind != sheetWord || !next.is("(") || next.line != t.line || next.col != t.col+len([]rune(t.text)) {
+		return v, nil
+	}
+	p.peeked = false
+	v.call = true
+
+	for {
+		t, err := p.peek()
+		if err != nil {
+			return v, err
+		}
+		if t.is(")") && len(v.args) == 0 {
+			p.peeked = false
+			return v, nil
+		}
+
+		arg, err := p.parseValue()
+		if err != nil {
+			return v, err
+		}
+		v.args = append(v.args, arg)
+
+		if t, err = p.next(); err != nil {
+			return v, err
+		}
+		if t.is(")") {
+			return v, nil
+		}
+		if !t.is(",") {
+			return v, t.errorf("expected ',' or ')', found %s", t)
+		}
+	}
+}
+
+// sheetBuilder builds styles from parsed rules.
+type sheetBuilder struct {
+	r        *Renderer
+	styles   map[string]Style
+	inherits map[string][]sheetValue
+
+	// The inheritance resolution state of each style: 1 while resolving,
+	// 2 once resolved.
+	state map[string]int
+}
+
+func (b *sheetBuilder) names() []string {
+	names := make([]string, 0, len(b.styles))
+	for name := range b.styles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// apply applies a rule to the styles it names.
+func (b *sheetBuilder) apply(rule sheetRule) error {
+	for _, name := range rule.names {
+		s, ok := b.styles[name.text]
+		if !ok {
+			s = b.r.NewStyle()
+		}
+		for _, decl := range rule.decls {
+			if decl.name.text == "inherit" {
+				b.inherits[name.text] = append(b.inherits[name.text], decl.values...)
+				continue
+			}
+			var err error
+			if s, err = applySheetDecl(b.r, s, decl, false); err != nil {
+				return err
+			}
+		}
+		b.styles[name.text] = s
+	}
+	return nil
+}
+
+// resolve applies inheritance to the named style, after resolving the
+// styles it inherits from.
+func (b *sheetBuilder) resolve(name string) error {
+	const (
+		resolving = 1
+		resolved  = 2
+	)
+	if b.state[name] == resolved {
+		return nil
+	}
+	b.state[name] = resolving
+
+	s := b.styles[name]
+	for _, parent := range b.inherits[name] {
+		if parent.call || parent.kind != sheetWord {
+			return parent.errorf("expected a style name, found %s", parent)
+		}
+		if _, ok := b.styles[parent.text]; !ok {
+			return parent.errorf("unknown style %s", parent)
+		}
+		if b.state[parent.text] == resolving {
+			return parent.errorf("circular inheritance of %s", parent)
+		}
+		if err := b.resolve(parent.text); err != nil {
+			return err
+		}
+		s = s.Inherit(b.styles[parent.text])
+	}
+
+	b.styles[name] = s
+	b.state[name] = resolved
+	return nil
+}
+
+// applySheetDecl applies a property declaration to a style. Shorthand
+// properties, like padding and border, set several properties at once using
+// the matching Style methods; the rest set a single property each.
+//
+// When exact is set, single properties take precedence over shorthands of
+// the same name, so that a property like underline-style sets nothing but
+// the underline style, as needed to restore a style exactly.
+func applySheetDecl(r *Renderer, s Style, decl sheetDecl, exact bool) (Style, error) {
+	short, isShort := sheetShorthands[decl.name.text]
+	prop, isProp := stylePropNames[decl.name.text]
+
+	switch {
+	case isShort && !(exact && isProp):
+		return short(s, decl.values)
+	case isProp:
+		v, err := prop.kind.parseText(r, decl.values)
+		if err != nil {
+			return s, err
+		}
+		s.set(prop.key, v)
+		return s, nil
+	default:
+		return s, decl.name.errorf("unknown property %s", decl.name)
+	}
+}
+
+// sheetShorthand applies a shorthand property's values to a style.
+type sheetShorthand func(s Style, values []sheetValue) (Style, error)
+
+// sheetShorthands maps shorthand property names to the properties.
+var sheetShorthands = map[string]sheetShorthand{
+	"padding":           intsShorthand(Style.Padding),
+	"margin":            intsShorthand(Style.Margin),
+	"align":             positionsShorthand(Style.Align),
+	"border-foreground": colorsShorthand(Style.BorderForeground),
+	"border-background": colorsShorthand(Style.BorderBackground),
+	"border":            borderShorthand,
+	"underline-style":   underlineStyleShorthand,
+}
+
+// checkValues checks that there are between min and max values.
+func checkValues(values []sheetValue, min, max int) error {
+	switch {
+	case len(values) < min:
+		return values[0].errorf("expected at least %d values, found %d", min, len(values))
+	case len(values) > max && max == 1:
+		return values[max].errorf("expected a single value")
+	case len(values) > max:
+		return values[max].errorf("expected at most %d values, found %d", max, len(values))
+	}
+	return nil
+}
+
+func intsShorthand(set func(Style, ...int) Style) sheetShorthand {
+	return func(s Style, values []sheetValue) (Style, error) {
+		if err := checkValues(values, 1, 4); err != nil { //nolint:gomnd
+			return s, err
+		}
+		ints := make([]int, len(values))
+		for i, v := range values {
+			var err error
+			if ints[i], err = parseSheetInt(v); err != nil {
+				return s, err
+			}
+		}
+		return set(s, ints...), nil
+	}
+}
+
+func positionsShorthand(set func(Style, ...Position) Style) sheetShorthand {
+	return func(s Style, values []sheetValue) (Style, error) {
+		if err := checkValues(values, 1, 2); err != nil { //nolint:gomnd
+			return s, err
+		}
+		positions := make([]Position, len(values))
+		for i, v := range values {
+			var err error
+			if positions[i], err = parseSheetPosition(v); err != nil {
+				return s, err
+			}
+		}
+		return set(s, positions...), nil
+	}
+}
+
+func colorsShorthand(set func(Style, ...TerminalColor) Style) sheetShorthand {
+	return func(s Style, values []sheetValue) (Style, error) {
+		if err := checkValues(values, 1, 4); err != nil { //nolint:gomnd
+			return s, err
+		}
+		colors := make([]TerminalColor, len(values))
+		for i, v := range values {
+			var err error
+			if colors[i], err = parseSheetColor(v); err != nil {
+				return s, err
+			}
+		}
+		return set(s, colors...), nil
+	}
+}
+
+func borderShorthand(s Style, values []sheetValue) (Style, error) {
+	if err := checkValues(values, 1, 5); err != nil { //nolint:gomnd
+		return s, err
+	}
+	b, err := parseSheetBorder(values[0])
+	if err != nil {
+		return s, err
+	}
+	sides := make([]bool, len(values)-1)
+	for i, v := range values[1:] {
+		if sides[i], err = parseSheetBool(v); err != nil {
+			return s, err
+		}
+	}
+	return s.Border(b, sides...), nil
+}
+
+func underlineStyleShorthand(s Style, values []sheetValue) (Style, error) {
+	if err := checkValues(values, 1, 1); err != nil {
+		return s, err
+	}
+	u, err := parseSheetUnderlineStyle(values[0])
+	return s.UnderlineStyle(u), err
+}
+
+// positionNames maps position names to positions.
+var positionNames = map[string]Position{
+	"left":   Left,
+	"center": Center,
+	"right":  Right,
+	"top":    Top,
+	"bottom": Bottom,
+}
+
+// isWord reports whether the value is a plain word, rather than a string or
+// a function call.
+func (v sheetValue) isWord() bool {
+	return v.kind == sheetWord && !v.call
+}
+
+// parseSheetText returns the text of a word or string.
+func parseSheetText(v sheetValue) (string, error) {
+	if v.call {
+		return "", v.errorf("expected a string, found %s", v)
+	}
+	return v.text, nil
+}
+
+func parseSheetBool(v sheetValue) (bool, error) {
+	if v.isWord() {
+		switch v.text {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	}
+	return false, v.errorf("expected true or false, found %s", v)
+}
+
+func parseSheetInt(v sheetValue) (int, error) {
+	if v.isWord() {
+		if n, err := strconv.Atoi(v.text); err == nil {
+			return n, nil
+		}
+	}
+	return 0, v.errorf("expected a whole number, found %s", v)
+}
+
+func parseSheetFloat(v sheetValue) (float64, error) {
+	if v.isWord() {
+		if f, err := strconv.ParseFloat(v.text, 64); err == nil {
+			return f, nil
+		}
+	}
+	return 0, v.errorf("expected a number, found %s", v)
+}
+
+func parseSheetPosition(v sheetValue) (Position, error) {
+	if v.isWord() {
+		if p, ok := positionNames[v.text]; ok {
+			return p, nil
+		}
+		if f, err := strconv.ParseFloat(v.text, 64); err == nil && !math.IsNaN(f) && !math.IsInf(f, 0) {
+			return Position(f), nil
+		}
+	}
+	return 0, v.errorf("expected a position, found %s", v)
+}
+
+func parseSheetUnderlineStyle(v sheetValue) (UnderlineStyle, error) {
+	if v.isWord() {
+		for u, name := range underlineStyleNames {
+			if name == v.text {
+				return UnderlineStyle(u), nil
+			}
+		}
+	}
+	return UnderlineNone, v.errorf("unknown underline style %s", v)
+}
+
+// parseSheetBorder parses a border by name, or a custom border given as
+// border("top", "bottom", "left", "right", ...) with the parts in the order
+// of the fields of Border.
+func parseSheetBorder(v sheetValue) (Border, error) {
+	if v.isWord() {
+		for _, b := range borderNames {
+			if b.name == v.text {
+				return b.border, nil
+			}
+		}
+	}
+	if !v.call || v.text != "border" {
+		return noBorder, v.errorf("unknown border %s", v)
+	}
+
+	parts := make([]string, len(borderParts(&Border{})))
+	if len(v.args) > len(parts) {
+		return noBorder, v.args[len(parts)].errorf("a border has at most %d parts", len(parts))
+	}
+	for i, arg := range v.args {
+		var err error
+		if parts[i], err = parseSheetText(arg); err != nil {
+			return noBorder, err
+		}
+	}
+
+	var b Border
+	for i, p := range borderParts(&b) {
+		*p = parts[i]
+	}
+	return b, nil
+}
+
+// parseSheetColor parses a color: a hex value, an ANSI color number, none,
+// a string holding any other color value, or a color function.
+func parseSheetColor(v sheetValue) (TerminalColor, error) {
+	switch {
+	case v.kind == sheetString:
+		return Color(v.text), nil
+	case v.isWord() && v.text == "none":
+		return noColor, nil
+	case v.isWord() && isColorWord(v.text):
+		return Color(v.text), nil
+	case v.isWord():
+		return noColor, v.errorf("invalid color %s", v)
+	}
+
+	// Check the number of arguments, and parse those that are colors.
+	colorArgs := func(min, max, colors int) ([]TerminalColor, error) {
+		if len(v.args) < min || (max >= 0 && len(v.args) > max) {
+			return nil, v.errorf("wrong number of arguments to %s", v)
+		}
+		args := make([]TerminalColor, len(v.args))
+		for i := 0; i < len(v.args) && (colors < 0 || i < colors); i++ {
+			var err error
+			if args[i], err = parseSheetColor(v.args[i]); err != nil {
+				return nil, err
+			}
+		}
+		return args, nil
+	}
+	floatArg := func(i int) (float64, error) {
+		return parseSheetFloat(v.args[i])
+	}
+
+	switch v.text {
+	case "ansi":
+		if len(v.args) != 1 {
+			return noColor, v.errorf("wrong number of arguments to %s", v)
+		}
+		n, err := parseSheetInt(v.args[0])
+		if err != nil || n < 0 {
+			return noColor, v.args[0].errorf("expected an ANSI color number, found %s", v.args[0])
+		}
+		return ANSIColor(n), nil
+
+	case "adaptive":
+		args, err := colorArgs(2, 2, 2) //nolint:gomnd
+		if err != nil {
+			return noColor, err
+		}
+		light, lok := args[0].(Color)
+		dark, dok := args[1].(Color)
+		if lok && dok {
+			return AdaptiveColor{Light: string(light), Dark: string(dark)}, nil
+		}
+		clight, lok := args[0].(CompleteColor)
+		cdark, dok := args[1].(CompleteColor)
+		if lok && dok {
+			return CompleteAdaptiveColor{Light: clight, Dark: cdark}, nil
+		}
+		return noColor, v.errorf("adaptive takes two plain colors or two complete colors")
+
+	case "complete":
+		if len(v.args) != 3 { //nolint:gomnd
+			return noColor, v.errorf("complete takes a true color, an ANSI 256 color and an ANSI color")
+		}
+		var parts [3]string
+		for i, arg := range v.args {
+			c, err := parseSheetColor(arg)
+			if err != nil {
+				return noColor, err
+			}
+			if _, ok := c.(Color); !ok && c != noColor {
+				return noColor, arg.errorf("expected a plain color, found %s", arg)
+			}
+			if c != noColor {
+				parts[i] = string(c.(Color))
+			}
+		}
+		return CompleteColor{TrueColor: parts[0], ANSI256: parts[1], ANSI: parts[2]}, nil
+
+	case "theme":
+		if len(v.args) != 1 {
+			return noColor, v.errorf("theme takes the name of a color")
+		}
+		name, err := parseSheetText(v.args[0])
+		return ThemeColor(name), err
+
+	case "gradient", "vertical-gradient":
+		args, err := colorArgs(0, -1, -1)
+		if err != nil {
+			return noColor, err
+		}
+		return Gradient{Colors: args, Vertical: v.text == "vertical-gradient"}, nil
+
+	case "lighten", "darken":
+		args, err := colorArgs(2, 2, 1) //nolint:gomnd
+		if err != nil {
+			return noColor, err
+		}
+		amount, err := floatArg(1)
+		if v.text == "darken" {
+			amount = -amount
+		}
+		return lightenedColor{c: args[0], amount: amount}, err
+
+	case "blend":
+		args, err := colorArgs(3, 3, 2) //nolint:gomnd
+		if err != nil {
+			return noColor, err
+		}
+		t, err := floatArg(2) //nolint:gomnd
+		return blendedColor{a: args[0], b: args[1], t: t}, err
+
+	case "complementary":
+		args, err := colorArgs(1, 1, 1)
+		if err != nil {
+			return noColor, err
+		}
+		return complementaryColor{c: args[0]}, nil
+
+	case "alpha":
+		args, err := colorArgs(3, 3, 2) //nolint:gomnd
+		if err != nil {
+			return noColor, err
+		}
+		alpha, err := floatArg(2) //nolint:gomnd
+		return alphaColor{c: args[0], bg: args[1], alpha: alpha}, err
+
+	case "ensure-contrast":
+		args, err := colorArgs(3, 3, 2) //nolint:gomnd
+		if err != nil {
+			return noColor, err
+		}
+		ratio, err := floatArg(2) //nolint:gomnd
+		return contrastingColor{fg: args[0], bg: args[1], ratio: ratio}, err
+
+	default:
+		return noColor, v.errorf("unknown color function %s", v)
+	}
+}
+
+// isColorWord reports whether s is a color that can be written without
+// quotes: a hex color or an ANSI color number.
+func isColorWord(s string) bool {
+	if strings.HasPrefix(s, "#") {
+		_, err := strconv.ParseUint(s[1:], 16, 32)
+		return err == nil && (len(s) == 4 || len(s) == 7) //nolint:gomnd
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n >= 0 && n <= 255 && strconv.Itoa(n) == s
+}
@@ -0,0 +1,158 @@
+package lipgloss
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestParseStyleSheet(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.TrueColor)
+
+	sheet, err := r.ParseStyleSheet(`
+/* The base style. */
+base {
+	foreground: #fafafa;
+	background: 63;
+	padding: 0 1;
+}
+
+// Titles build on the base style.
+title {
+	inherit: base;
+	bold: true;
+	border: rounded false true;
+	border-foreground: #7d56f4
+}
+
+error, warning { foreground: #ff5f5f; }
+warning { italic: true; }
+
+label {
+	width: 9;
+	align: center;
+	border-title: "{\"hi\"}";
+	foreground: gradient(#ff0000, theme(accent));
+}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tt := []struct {
+		name     string
+		expected Style
+	}{
+		{
+			"base",
+			r.NewStyle().Foreground(Color("#fafafa")).Background(Color("63")).Padding(0, 1),
+		},
+		{
+			"title",
+			r.NewStyle().
+				Bold(true).
+				Border(RoundedBorder(), false, true).
+				BorderForeground(Color("#7d56f4")).
+				Foreground(Color("#fafafa")).
+				Background(Color("63")),
+		},
+		{
+			"error",
+			r.NewStyle().Foreground(Color("#ff5f5f")),
+		},
+		{
+			"warning",
+			r.NewStyle().Foreground(Color("#ff5f5f")).Italic(true),
+		},
+		{
+			"missing",
+			r.NewStyle(),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := sheet.Style(tc.name).Renderer(r).Render("hello")
+			expected := tc.expected.Render("hello")
+			if res != expected {
+				t.Errorf("Expected:\n\n`%s`\n`%s`\n\nActual output:\n\n`%s`\n`%s`\n\n",
+					expected, formatEscapes(expected),
+					res, formatEscapes(res))
+			}
+		})
+	}
+
+	label := sheet.Style("label")
+	if title := label.GetBorderTitle(); title != `{"hi"}` {
+		t.Errorf("Expected border title %q, got %q", `{"hi"}`, title)
+	}
+	if g, ok := label.GetForeground().(Gradient); !ok || len(g.Colors) != 2 || g.Colors[1] != ThemeColor("accent") {
+		t.Errorf("Expected a gradient, got %#v", label.GetForeground())
+	}
+	if w, a := label.GetWidth(), label.GetAlignHorizontal(); w != 9 || a != Center {
+		t.Errorf("Expected width 9 centered, got %d at %v", w, a)
+	}
+
+	names := sheet.Names()
+	if len(names) != 5 || names[0] != "base" || names[4] != "warning" {
+		t.Errorf("Unexpected names %v", names)
+	}
+
+	// Changing a style we got from the sheet mustn't change the sheet.
+	sheet.Style("error").Bold(true)
+	if sheet.Style("error").GetBold() {
+		t.Error("Expected style sheet styles to be copied")
+	}
+}
+
+func TestStyleSheetTabWidth(t *testing.T) {
+	sheet, err := ParseStyleSheet("a { tab-width: -5; }")
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := sheet.Style("a")
+	if w := st.GetTabWidth(); w != NoTabConversion {
+		t.Errorf("Expected tab width %d, got %d", NoTabConversion, w)
+	}
+	if res := st.Render("a\tb"); res != "a\tb" {
+		t.Errorf("Expected tabs to be kept, got %q", res)
+	}
+}
+
+func TestParseStyleSheetErrors(t *testing.T) {
+	tt := []struct {
+		src          string
+		line, column int
+		msg          string
+	}{
+		{"a { bold: yes; }", 1, 11, "expected true or false, found 'yes'"},
+		{"a {\n  colour: red;\n}", 2, 3, "unknown property 'colour'"},
+		{"a {\n  padding: 1 2 3 4 5;\n}", 2, 20, "expected at most 4 values, found 5"},
+		{"a { foreground: #12345g; }", 1, 17, "invalid color '#12345g'"},
+		{"a { foreground: adaptive(#fff); }", 1, 17, "wrong number of arguments to 'adaptive'"},
+		{"a { border: squiggly; }", 1, 13, "unknown border 'squiggly'"},
+		{"a { bold: true }\nb { inherit: c; }", 2, 14, "unknown style 'c'"},
+		{"a { inherit: b; }\nb { inherit: a; }", 2, 14, "circular inheritance of 'a'"},
+		{"a { bold true; }", 1, 10, "expected ':', found 'true'"},
+		{"a { bold: true;", 1, 16, "expected a property name, found end of file"},
+		{"a b { }", 1, 3, "expected ',' or '{', found 'b'"},
+		{"/* a { }", 1, 1, "unterminated comment"},
+		{"a { border-title: \"hi }", 1, 19, "unterminated string"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.src, func(t *testing.T) {
+			_, err := ParseStyleSheet(tc.src)
+			var serr *StyleSheetError
+			if !errors.As(err, &serr) {
+				t.Fatalf("Expected a StyleSheetError, got %v", err)
+			}
+			if serr.Line != tc.line || serr.Column != tc.column || serr.Msg != tc.msg {
+				t.Errorf("Expected %d:%d: %s, got %s", tc.line, tc.column, tc.msg, serr)
+			}
+		})
+	}
+}
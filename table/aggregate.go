@@ -0,0 +1,101 @@
+package table
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Aggregator summarizes the numeric values of a column, to show in the
+// table's footer. See Table.Aggregate.
+type Aggregator func(values []float64) float64
+
+// Sum is an Aggregator that adds up the values.
+func Sum(values []float64) float64 {
+	var total float64
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// Avg is an Aggregator that returns the mean of the values, or 0 if there are
+// none.
+func Avg(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	return Sum(values) / float64(len(values))
+}
+
+// Count is an Aggregator that returns the number of values.
+func Count(values []float64) float64 {
+	return float64(len(values))
+}
+
+// Min is an Aggregator that returns the smallest of the values, or 0 if there
+// are none.
+func Min(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+// Max is an Aggregator that returns the largest of the values, or 0 if there
+// are none.
+func Max(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	m := values[0]
+	for _, v := range values[1:] {
+		if v > m {
+			m = v
+		}
+	}
+	return m
+}
+
+// aggregate is an aggregator set on a column of the table.
+type aggregate struct {
+	fn     Aggregator
+	format string
+}
+
+// compute runs the aggregator over the numeric cells of the given column and
+// formats the result. Cells that aren't numbers are left out.
+func (a aggregate) compute(data Data, col int) string {
+	var values []float64
+	for r := 0; r < data.Rows(); r++ {
+		v, err := strconv.ParseFloat(strings.TrimSpace(data.At(r, col)), 64)
+		if err == nil {
+			values = append(values, v)
+		}
+	}
+
+	v := a.fn(values)
+	if a.format == "" {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprintf(a.format, v)
+}
+
+// footerCells returns the contents of the footer cells, one for each of the
+// given number of columns, filling in the aggregates.
+func (t *Table) footerCells(columns int) []string {
+	cells := make([]string, columns)
+	copy(cells, t.footers)
+	for col, a := range t.aggregates {
+		if col < columns && t.data != nil {
+			cells[col] = a.compute(t.data, col)
+		}
+	}
+	return cells
+}
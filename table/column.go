@@ -0,0 +1,112 @@
+package table
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Column describes how a table column is sized and aligned. When the table
+// is given a width, columns are grown or shrunk to fit it within their
+// constraints.
+//
+// Example:
+//
+//	t := table.New().
+//	    Width(60).
+//	    Headers("ID", "Description", "Amount").
+//	    Columns(
+//	        table.NewColumn().NoShrink(true),
+//	        table.NewColumn().Flex(1),
+//	        table.NewColumn().MinWidth(8).Align(lipgloss.Right),
+//	    )
+type Column struct {
+	width    int
+	minWidth int
+	maxWidth int
+	flex     int
+	noShrink bool
+
+	align    lipgloss.Position
+	hasAlign bool
+}
+
+// NewColumn returns a column with no constraints. Its width is worked out
+// from its contents.
+func NewColumn() Column {
+	return Column{}
+}
+
+// Width fixes the width of the column, regardless of its contents and the
+// width of the table. Cells that don't fit are truncated.
+func (c Column) Width(w int) Column {
+	c.width = max(0, w)
+	return c
+}
+
+// MinWidth sets the minimum width of the column.
+func (c Column) MinWidth(w int) Column {
+	c.minWidth = max(0, w)
+	return c
+}
+
+// MaxWidth sets the maximum width of the column. Cells that don't fit are
+// truncated.
+func (c Column) MaxWidth(w int) Column {
+	c.maxWidth = max(0, w)
+	return c
+}
+
+// Flex sets the flex weight of the column. When the table is wider or
+// narrower than its contents, columns with a flex weight absorb the
+// difference first, in proportion to their weights, before any other
+// columns are resized.
+func (c Column) Flex(weight int) Column {
+	c.flex = max(0, weight)
+	return c
+}
+
+// NoShrink sets whether the column must never be shrunk below the width of
+// its contents to fit the table's width.
+func (c Column) NoShrink(v bool) Column {
+	c.noShrink = v
+	return c
+}
+
+// Align sets the horizontal alignment of the cells in the column. Styles
+// returned by the table's StyleFunc that set an alignment of their own take
+// precedence.
+func (c Column) Align(p lipgloss.Position) Column {
+	c.align = p
+	c.hasAlign = true
+	return c
+}
+
+// constrain returns the width of the column given the width of its
+// contents.
+func (c Column) constrain(w int) int {
+	if c.width > 0 {
+		return c.width
+	}
+	if c.maxWidth > 0 {
+		w = min(w, c.maxWidth)
+	}
+	return max(w, c.minWidth)
+}
+
+// canGrow reports whether the column can be made wider than w.
+func (c Column) canGrow(w int) bool {
+	return c.width == 0 && (c.maxWidth == 0 || w < c.maxWidth)
+}
+
+// canShrink reports whether the column can be made narrower than w.
+func (c Column) canShrink(w int) bool {
+	return c.width == 0 && !c.noShrink && w > c.minWidth
+}
+
+// style applies the column's alignment to the style of one of its cells,
+// unless the style sets an alignment of its own.
+func (c Column) style(s lipgloss.Style) lipgloss.Style {
+	if !c.hasAlign {
+		return s
+	}
+	return s.Inherit(lipgloss.NewStyle().Align(c.align))
+}
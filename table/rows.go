@@ -12,10 +12,33 @@ type Data interface {
 	Columns() int
 }
 
+// Versioned is implemented by Data that keeps count of its changes, so that
+// data wrapping it, such as Filter and Sorted, can tell when to work out its
+// rows again.
+type Versioned interface {
+	// Version returns a number that changes whenever the data does.
+	Version() int
+}
+
+// version returns the version of the data, or 0 if it isn't Versioned.
+func version(data Data) int {
+	if v, ok := data.(Versioned); ok {
+		return v.Version()
+	}
+	return 0
+}
+
 // StringData is a string-based implementation of the Data interface.
 type StringData struct {
 	rows    [][]string
 	columns int
+
+	// spans tracks the number of rows and columns spanned by the cells that
+	// span, by their position.
+	spans map[[2]int][2]int
+
+	// version counts the changes made to the data.
+	version int
 }
 
 // NewStringData creates a new StringData with the given number of columns.
@@ -34,6 +57,7 @@ func NewStringData(rows ...[]string) *StringData {
 func (m *StringData) Append(row []string) {
 	m.columns = max(m.columns, len(row))
 	m.rows = append(m.rows, row)
+	m.version++
 }
 
 // At returns the contents of the cell at the given index.
@@ -54,18 +78,66 @@ func (m *StringData) Columns() int {
 func (m *StringData) Item(rows ...string) *StringData {
 	m.columns = max(m.columns, len(rows))
 	m.rows = append(m.rows, rows)
+	m.version++
 	return m
 }
 
+// Set sets the contents of the cell at the given index, if it's in the
+// table.
+func (m *StringData) Set(row, cell int, value string) *StringData {
+	if row < 0 || row >= len(m.rows) || cell < 0 || cell >= len(m.rows[row]) {
+		return m
+	}
+	m.rows[row][cell] = value
+	m.version++
+	return m
+}
+
+// Version returns the number of changes made to the data. Changes made to
+// rows after they've been added, other than with Set, aren't counted.
+func (m *StringData) Version() int {
+	return m.version
+}
+
 // Rows returns the number of rows in the table.
 func (m *StringData) Rows() int {
 	return len(m.rows)
 }
 
+// SetSpan makes the cell at the given index span the given number of rows and
+// columns, covering the cells to its right and below it.
+func (m *StringData) SetSpan(row, cell, rows, columns int) *StringData {
+	if m.spans == nil {
+		m.spans = make(map[[2]int][2]int)
+	}
+	m.spans[[2]int{row, cell}] = [2]int{max(1, rows), max(1, columns)}
+	m.version++
+	return m
+}
+
+// Span returns the number of rows and columns the cell at the given index
+// spans.
+func (m *StringData) Span(row, cell int) (rows, columns int) {
+	if span, ok := m.spans[[2]int{row, cell}]; ok {
+		return span[0], span[1]
+	}
+	return 1, 1
+}
+
 // Filter applies a filter on some data.
 type Filter struct {
 	data   Data
 	filter func(row int) bool
+
+	// index maps the filtered rows to the rows of the data. It's worked out
+	// when it's first needed, and again when the filter or the data change.
+	index       []int
+	rows        int
+	dataVersion int
+	filtered    bool
+
+	// version counts the changes made to the filter.
+	version int
 }
 
 // NewFilter initializes a new Filter.
@@ -73,28 +145,54 @@ func NewFilter(data Data) *Filter {
 	return &Filter{data: data}
 }
 
-// Filter applies the given filter function to the data.
+// Filter applies the given filter function to the data. The rows it matches
+// are worked out once, and again when the data changes, if it's Versioned, or
+// when rows are added to or removed from it. If anything else the filter
+// depends on changes, call Filter again, or Invalidate.
 func (m *Filter) Filter(f func(row int) bool) *Filter {
 	m.filter = f
+	m.Invalidate()
 	return m
 }
 
+// Invalidate makes the filter work out the rows it matches again, for when
+// the data has changed in a way it can't tell.
+func (m *Filter) Invalidate() {
+	m.filtered = false
+	m.version++
+}
+
+// Version returns a number that changes whenever the filter or its data
+// change.
+func (m *Filter) Version() int {
+	return m.version + version(m.data)
+}
+
+// Index returns the row of the underlying data at the given filtered row, or
+// -1 if there's no such row.
+func (m *Filter) Index(row int) int {
+	m.apply()
+	if row < 0 || row >= len(m.index) {
+		return -1
+	}
+	return m.index[row]
+}
+
 // Row returns the row at the given index.
 func (m *Filter) At(row, cell int) string {
-	j := 0
-	for i := 0; i < m.data.Rows(); i++ {
-		if m.filter(i) {
-			if j == row {
-				return m.data.At(i, cell)
-			}
-
-			j++
-		}
+	if i := m.Index(row); i >= 0 {
+		return m.data.At(i, cell)
 	}
-
 	return ""
 }
 
+// Span returns the number of columns the cell at the given index spans, if
+// the data is a Spanner. Cells never span rows once filtered, as the rows
+// they covered may have been filtered out. It implements Spanner.
+func (m *Filter) Span(row, cell int) (rows, columns int) {
+	return spanColumns(m.data, m.Index(row), cell)
+}
+
 // Columns returns the number of columns in the table.
 func (m *Filter) Columns() int {
 	return m.data.Columns()
@@ -102,12 +200,23 @@ func (m *Filter) Columns() int {
 
 // Rows returns the number of rows in the table.
 func (m *Filter) Rows() int {
-	j := 0
-	for i := 0; i < m.data.Rows(); i++ {
-		if m.filter(i) {
-			j++
-		}
+	m.apply()
+	return len(m.index)
+}
+
+// apply works out the rows the filter matches, if they're out of date.
+func (m *Filter) apply() {
+	rows, v := m.data.Rows(), version(m.data)
+	if m.filtered && m.rows == rows && m.dataVersion == v {
+		return
 	}
 
-	return j
+	m.index = m.index[:0]
+	for i := 0; i < rows; i++ {
+		if m.filter == nil || m.filter(i) {
+			m.index = append(m.index, i)
+		}
+	}
+	m.rows, m.dataVersion = rows, v
+	m.filtered = true
 }
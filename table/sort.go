@@ -0,0 +1,376 @@
+package table
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SortOrder is the order rows are sorted in.
+type SortOrder int
+
+// Available sort orders.
+const (
+	Ascending SortOrder = iota
+	Descending
+)
+
+// Comparator compares two cells, returning a negative number if a sorts
+// before b, a positive number if a sorts after b, and 0 if they're equal.
+// Sorting in Descending order reverses the result, unless it's AlwaysBefore
+// or AlwaysAfter.
+type Comparator func(a, b string) int
+
+// Comparators return AlwaysBefore or AlwaysAfter to put a cell before or after
+// another whichever order the column is sorted in. The comparators that parse
+// cells use them to keep the cells that don't parse after those that do.
+const (
+	AlwaysBefore = math.MinInt32
+	AlwaysAfter  = math.MaxInt32
+)
+
+// SortKey is a column to sort rows by.
+type SortKey struct {
+	// Column is the index of the column.
+	Column int
+
+	// Order is the order to sort the column in.
+	Order SortOrder
+
+	// Compare compares the column's cells. If it's nil, they're compared as
+	// strings.
+	Compare Comparator
+}
+
+// Sorted sorts some data by one or more columns. The sort is stable, so rows
+// that compare equal keep the order they have in the data.
+//
+// Example:
+//
+//	sorted := table.NewSorted(data).Sort(
+//	    table.SortKey{Column: 2, Order: table.Descending, Compare: table.CompareNumbers},
+//	    table.SortKey{Column: 0},
+//	)
+//	t := table.New().Data(sorted)
+type Sorted struct {
+	data Data
+	keys []SortKey
+
+	// index maps the sorted rows to the rows of the data. It's worked out
+	// when it's first needed, and again when the keys or the data change.
+	index       []int
+	dataVersion int
+	sorted      bool
+
+	// version counts the changes made to the keys.
+	version int
+}
+
+// NewSorted initializes a new Sorted, in the order of the data until it's
+// given keys to sort by.
+func NewSorted(data Data) *Sorted {
+	return &Sorted{data: data}
+}
+
+// Sort sets the columns to sort by, replacing any set before. Rows are
+// sorted by the first key; rows that compare equal by it are sorted by the
+// second, and so on. Rows are sorted again when the data changes, if it's
+// Versioned, or when rows are added to or removed from it. Otherwise, call
+// Sort again, or Invalidate.
+func (m *Sorted) Sort(keys ...SortKey) *Sorted {
+	m.keys = keys
+	m.Invalidate()
+	return m
+}
+
+// Invalidate makes the rows be sorted again, for when the data has changed in
+// a way that can't be told.
+func (m *Sorted) Invalidate() {
+	m.sorted = false
+	m.version++
+}
+
+// Version returns a number that changes whenever the keys or the data change.
+func (m *Sorted) Version() int {
+	return m.version + version(m.data)
+}
+
+// Keys returns the columns the data is sorted by.
+func (m *Sorted) Keys() []SortKey {
+	return m.keys
+}
+
+// Order returns the order the given column is sorted in, and whether it's
+// sorted by at all.
+func (m *Sorted) Order(column int) (SortOrder, bool) {
+	for _, key := range m.keys {
+		if key.Column == column {
+			return key.Order, true
+		}
+	}
+	return Ascending, false
+}
+
+// Index returns the row of the underlying data at the given sorted row, or
+// -1 if there's no such row.
+func (m *Sorted) Index(row int) int {
+	m.sort()
+	if row < 0 || row >= len(m.index) {
+		return -1
+	}
+	return m.index[row]
+}
+
+// At returns the contents of the cell at the given index.
+func (m *Sorted) At(row, cell int) string {
+	if i := m.Index(row); i >= 0 {
+		return m.data.At(i, cell)
+	}
+	return ""
+}
+
+// Span returns the number of columns the cell at the given index spans, if
+// the data is a Spanner. Cells never span rows once sorted, as the rows they
+// covered may have been sorted apart. It implements Spanner.
+func (m *Sorted) Span(row, cell int) (rows, columns int) {
+	return spanColumns(m.data, m.Index(row), cell)
+}
+
+// Columns returns the number of columns in the table.
+func (m *Sorted) Columns() int {
+	return m.data.Columns()
+}
+
+// Rows returns the number of rows in the table.
+func (m *Sorted) Rows() int {
+	return m.data.Rows()
+}
+
+// sort works out the order of the rows, if it's out of date.
+func (m *Sorted) sort() {
+	v := version(m.data)
+	if m.sorted && len(m.index) == m.data.Rows() && m.dataVersion == v {
+		return
+	}
+
+	m.index = make([]int, m.data.Rows())
+	for i := range m.index {
+		m.index[i] = i
+	}
+	sort.SliceStable(m.index, func(i, j int) bool {
+		a, b := m.index[i], m.index[j]
+		for _, key := range m.keys {
+			compare := key.Compare
+			if compare == nil {
+				compare = CompareStrings
+			}
+			c := compare(m.data.At(a, key.Column), m.data.At(b, key.Column))
+			if key.Order == Descending && c != AlwaysBefore && c != AlwaysAfter {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+	m.dataVersion = v
+	m.sorted = true
+}
+
+// CompareStrings is a Comparator that compares cells as strings, byte by
+// byte.
+func CompareStrings(a, b string) int {
+	return strings.Compare(a, b)
+}
+
+// CompareNatural is a Comparator that compares cells as strings, except that
+// runs of digits are compared as numbers, so that "file2" sorts before
+// "file10".
+func CompareNatural(a, b string) int {
+	for a != "" && b != "" {
+		da, db := isDigit(a[0]), isDigit(b[0])
+		if !da || !db {
+			if a[0] != b[0] {
+				return int(a[0]) - int(b[0])
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+
+		// Compare the runs of digits by their value, ignoring leading
+		// zeros, which means by length first.
+		na, nb := digits(a), digits(b)
+		ta, tb := strings.TrimLeft(a[:na], "0"), strings.TrimLeft(b[:nb], "0")
+		if len(ta) != len(tb) {
+			return len(ta) - len(tb)
+		}
+		if c := strings.Compare(ta, tb); c != 0 {
+			return c
+		}
+		a, b = a[na:], b[nb:]
+	}
+	return len(a) - len(b)
+}
+
+// CompareNumbers is a Comparator that compares cells as numbers. Cells that
+// aren't numbers sort after those that are, in either order, and are compared
+// naturally amongst themselves.
+func CompareNumbers(a, b string) int {
+	fa, errA := strconv.ParseFloat(strings.TrimSpace(a), 64)
+	fb, errB := strconv.ParseFloat(strings.TrimSpace(b), 64)
+	switch {
+	case errA != nil && errB != nil:
+		return CompareNatural(a, b)
+	case errA != nil:
+		return AlwaysAfter
+	case errB != nil:
+		return AlwaysBefore
+	case fa < fb:
+		return -1
+	case fa > fb:
+		return 1
+	}
+	return 0
+}
+
+// CompareTimes returns a Comparator that compares cells as times in the given
+// layout, as understood by time.Parse. Cells that aren't times sort after
+// those that are, in either order, and are compared naturally amongst
+// themselves.
+func CompareTimes(layout string) Comparator {
+	return func(a, b string) int {
+		ta, errA := time.Parse(layout, strings.TrimSpace(a))
+		tb, errB := time.Parse(layout, strings.TrimSpace(b))
+		switch {
+		case errA != nil && errB != nil:
+			return CompareNatural(a, b)
+		case errA != nil:
+			return AlwaysAfter
+		case errB != nil:
+			return AlwaysBefore
+		case ta.Before(tb):
+			return -1
+		case ta.After(tb):
+			return 1
+		}
+		return 0
+	}
+}
+
+// CompareSemver is a Comparator that compares cells as semantic versions,
+// such as "v1.2.3" or "2.0.0-rc.1", by the rules of semver.org: pre-release
+// versions sort before the release, and build metadata is ignored. Missing
+// minor and patch numbers count as 0. Cells that aren't versions sort after
+// those that are, in either order, and are compared naturally amongst
+// themselves.
+func CompareSemver(a, b string) int {
+	va, okA := parseSemver(a)
+	vb, okB := parseSemver(b)
+	switch {
+	case !okA && !okB:
+		return CompareNatural(a, b)
+	case !okA:
+		return AlwaysAfter
+	case !okB:
+		return AlwaysBefore
+	}
+
+	for i := range va.numbers {
+		if va.numbers[i] != vb.numbers[i] {
+			if va.numbers[i] < vb.numbers[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	// A version without a pre-release sorts after one with.
+	switch {
+	case va.pre == nil && vb.pre == nil:
+		return 0
+	case va.pre == nil:
+		return 1
+	case vb.pre == nil:
+		return -1
+	}
+	for i := 0; i < len(va.pre) && i < len(vb.pre); i++ {
+		if c := comparePrerelease(va.pre[i], vb.pre[i]); c != 0 {
+			return c
+		}
+	}
+	return len(va.pre) - len(vb.pre)
+}
+
+// semver is a parsed semantic version.
+type semver struct {
+	numbers [3]uint64
+	pre     []string
+}
+
+// parseSemver parses a semantic version, with or without a leading "v".
+func parseSemver(str string) (semver, bool) {
+	var v semver
+	str = strings.TrimPrefix(strings.TrimSpace(str), "v")
+	if i := strings.IndexByte(str, '+'); i >= 0 {
+		str = str[:i]
+	}
+	if i := strings.IndexByte(str, '-'); i >= 0 {
+		v.pre = strings.Split(str[i+1:], ".")
+		str = str[:i]
+		for _, id := range v.pre {
+			if id == "" {
+				return v, false
+			}
+		}
+	}
+
+	parts := strings.Split(str, ".")
+	if len(parts) > len(v.numbers) {
+		return v, false
+	}
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return v, false
+		}
+		v.numbers[i] = n
+	}
+	return v, true
+}
+
+// comparePrerelease compares two pre-release identifiers. Numeric
+// identifiers are compared as numbers and sort before alphanumeric ones.
+func comparePrerelease(a, b string) int {
+	na, errA := strconv.ParseUint(a, 10, 64)
+	nb, errB := strconv.ParseUint(b, 10, 64)
+	switch {
+	case errA == nil && errB == nil:
+		if na < nb {
+			return -1
+		} else if na > nb {
+			return 1
+		}
+		return 0
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// digits returns the length of the run of digits at the start of str.
+func digits(str string) int {
+	i := 0
+	for i < len(str) && isDigit(str[i]) {
+		i++
+	}
+	return i
+}
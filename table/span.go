@@ -0,0 +1,278 @@
+package table
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Spanner is implemented by Data whose cells can span several rows or
+// columns. A spanning cell covers the cells to its right and below it, which
+// aren't drawn.
+type Spanner interface {
+	// Span returns the number of rows and columns the cell at the given
+	// index spans. Both are 1 for a cell that doesn't span.
+	Span(row, cell int) (rows, columns int)
+}
+
+// spanColumns returns the span of the cell at the given row of some data,
+// keeping only the columns it spans, for data that reorders or leaves out the
+// rows of the data it wraps.
+func spanColumns(data Data, row, cell int) (rows, columns int) {
+	spanner, ok := data.(Spanner)
+	if !ok || row < 0 {
+		return 1, 1
+	}
+	_, columns = spanner.Span(row, cell)
+	return 1, columns
+}
+
+// cellSpan describes the cell that covers a position of the table's grid.
+type cellSpan struct {
+	// The position of the cell in the grid, where the headers, if any, are
+	// the first row.
+	row, col int
+
+	// The number of rows and columns the cell spans.
+	rows, cols int
+}
+
+// HeaderSpan makes the header at the given index span the given number of
+// columns, covering the headers to its right. This is handy for grouping
+// columns under a common heading.
+//
+// Example:
+//
+//	t := table.New().
+//	    Headers("", "Q1", "", "", "Q2").
+//	    HeaderSpan(1, 3).
+//	    HeaderSpan(4, 3).
+//	    Row("", "Jan", "Feb", "Mar", "Apr", "May", "Jun")
+func (t *Table) HeaderSpan(index, columns int) *Table {
+	if index < 0 {
+		return t
+	}
+	for len(t.headerSpans) <= index {
+		t.headerSpans = append(t.headerSpans, 1)
+	}
+	t.headerSpans[index] = max(1, columns)
+	return t
+}
+
+// layoutSpans works out which cells of the table cover which others. Spans
+// are cut short where they would run off the table or into another spanning
+// cell, and headers only span columns. If no cell spans, the grid is left
+// nil.
+func (t *Table) layoutSpans(hasHeaders bool) {
+	t.spans = nil
+	t.spanLines = nil
+
+	spanner, _ := t.data.(Spanner)
+	spanAt := func(row, col int) (int, int) {
+		if hasHeaders && row == 0 {
+			if col < len(t.headerSpans) {
+				return 1, t.headerSpans[col]
+			}
+			return 1, 1
+		}
+		if spanner == nil || col >= t.data.Columns() {
+			return 1, 1
+		}
+		rows, cols := spanner.Span(row-btoi(hasHeaders), col)
+		return max(1, rows), max(1, cols)
+	}
+
+	rows, cols := btoi(hasHeaders)+t.data.Rows(), len(t.widths)
+	spanning := false
+	for r := 0; r < rows && !spanning; r++ {
+		for c := 0; c < cols; c++ {
+			if rs, cs := spanAt(r, c); rs > 1 || cs > 1 {
+				spanning = true
+				break
+			}
+		}
+	}
+	if !spanning {
+		return
+	}
+
+	// A zero cellSpan marks a position that isn't covered yet.
+	grid := make([][]cellSpan, rows)
+	for r := range grid {
+		grid[r] = make([]cellSpan, cols)
+	}
+	for r := range grid {
+		for c := range grid[r] {
+			if grid[r][c].rows > 0 {
+				continue
+			}
+
+			rs, cs := spanAt(r, c)
+			if hasHeaders && r == 0 {
+				rs = 1
+			}
+			rs, cs = min(rs, rows-r), min(cs, cols-c)
+			for i := c + 1; i < c+cs; i++ {
+				if grid[r][i].rows > 0 {
+					cs = i - c
+					break
+				}
+			}
+		rowsLoop:
+			for i := r + 1; i < r+rs; i++ {
+				for j := c; j < c+cs; j++ {
+					if grid[i][j].rows > 0 {
+						rs = i - r
+						break rowsLoop
+					}
+				}
+			}
+
+			span := cellSpan{row: r, col: c, rows: rs, cols: cs}
+			for i := r; i < r+rs; i++ {
+				for j := c; j < c+cs; j++ {
+					grid[i][j] = span
+				}
+			}
+		}
+	}
+	t.spans = grid
+	t.spanLines = make(map[cellSpan][]string)
+}
+
+// span returns the cell covering the given position of the grid.
+func (t *Table) span(row, col int) cellSpan {
+	if t.spans == nil || row < 0 || row >= len(t.spans) {
+		return cellSpan{row: row, col: col, rows: 1, cols: 1}
+	}
+	return t.spans[row][col]
+}
+
+// spanning reports whether the cell covering the given position of the grid
+// spans several rows or columns.
+func (t *Table) spanning(row, col int) bool {
+	span := t.span(row, col)
+	return span.rows > 1 || span.cols > 1
+}
+
+// joined reports whether the columns either side of the column separator
+// before col are covered by the same cell in the given row, so that there's
+// no separator to draw.
+func (t *Table) joined(row, col int) bool {
+	if t.spans == nil || row < 0 || row >= len(t.spans) {
+		return false
+	}
+	a, b := t.spans[row][col-1], t.spans[row][col]
+	return a.row == b.row && a.col == b.col
+}
+
+// continues reports whether the cell covering the given position of the grid
+// carries on into the next row, across the row separator.
+func (t *Table) continues(row, col int) bool {
+	return t.spans != nil && row+1 < len(t.spans) && t.spans[row+1][col].row <= row
+}
+
+// spanWidth returns the width of a cell spanning n columns from col,
+// including the column separators it covers.
+func (t *Table) spanWidth(col, n int) int {
+	return sum(t.widths[col:col+n]) + (n-1)*btoi(t.borderColumn)
+}
+
+// spanHeight returns the height of a cell spanning n rows from row, including
+// the row separators it covers.
+func (t *Table) spanHeight(row, n int) int {
+	return sum(t.heights[row:row+n]) + (n-1)*btoi(t.borderRow)
+}
+
+// fitSpans makes room for the cells that span several columns or rows, once
+// the other cells have been measured. Whatever a spanning cell needs on top of
+// the columns and rows it spans is spread evenly across them.
+func (t *Table) fitSpans(hasHeaders bool) {
+	if t.spans == nil {
+		return
+	}
+
+	// Rows made up only of spanned cells weren't measured.
+	for r := range t.heights {
+		t.heights[r] = max(t.heights[r], 1)
+	}
+
+	for r, row := range t.spans {
+		for c, span := range row {
+			if span.row != r || span.col != c || (span.rows == 1 && span.cols == 1) {
+				continue
+			}
+
+			var rendered string
+			if hasHeaders && r == 0 {
+				rendered = t.style(0, c).Render(t.header(c))
+			} else {
+				rendered = t.style(r-btoi(hasHeaders)+1, c).Render(t.data.At(r-btoi(hasHeaders), c))
+			}
+			grow(t.widths[c:c+span.cols], lipgloss.Width(rendered)-t.spanWidth(c, span.cols))
+			grow(t.heights[r:r+span.rows], lipgloss.Height(rendered)-t.spanHeight(r, span.rows))
+		}
+	}
+}
+
+// grow spreads n evenly across sizes.
+func grow(sizes []int, n int) {
+	for i := 0; i < n; i++ {
+		sizes[i%len(sizes)]++
+	}
+}
+
+// spanLine returns line y of a cell spanning several rows. The cell is
+// rendered the first time one of its lines is needed.
+func (t *Table) spanLine(span cellSpan, y int) string {
+	lines, ok := t.spanLines[span]
+	if !ok {
+		hasHeaders := t.headers != nil && len(t.headers) > 0
+		row := span.row - btoi(hasHeaders)
+		width, height := t.spanWidth(span.col, span.cols), t.spanHeight(span.row, span.rows)
+		lines = strings.Split(t.style(row+1, span.col).
+			Height(height).
+			MaxHeight(height).
+			Width(width).
+			MaxWidth(width).
+			Ellipsis("…").
+			Render(t.data.At(row, span.col)), "\n")
+		t.spanLines[span] = lines
+	}
+	if y < 0 || y >= len(lines) {
+		return strings.Repeat(" ", t.spanWidth(span.col, span.cols))
+	}
+	return lines[y]
+}
+
+// junction returns the border glyph where a column separator meets a row
+// separator, given which of the four lines meeting there are drawn:
+// the column separator above and below, and the row separator to the left and
+// right. horizontal is the glyph of the row separator.
+func (t *Table) junction(up, down, left, right bool, horizontal string) string {
+	switch {
+	case up && down && left && right:
+		return t.border.Middle
+	case down && left && right:
+		return t.border.MiddleTop
+	case up && left && right:
+		return t.border.MiddleBottom
+	case up && down && right:
+		return t.border.MiddleLeft
+	case up && down && left:
+		return t.border.MiddleRight
+	case left && right:
+		return horizontal
+	case up && down:
+		return t.border.Left
+	case down && right:
+		return t.border.TopLeft
+	case down && left:
+		return t.border.TopRight
+	case up && right:
+		return t.border.BottomLeft
+	case up && left:
+		return t.border.BottomRight
+	}
+	return " "
+}
@@ -1,10 +1,10 @@
 package table
 
 import (
+	"io"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
-	"github.com/mattn/go-runewidth"
 )
 
 // StyleFunc is the style function that determines the style of a Cell.
@@ -31,6 +31,10 @@ import (
 //	    })
 type StyleFunc func(row, col int) lipgloss.Style
 
+// FooterRow is the row StyleFunc is called with for the footer cells. The
+// headers are row 0 and the other rows follow on from 1.
+const FooterRow = -1
+
 // DefaultStyles is a TableStyleFunc that returns a new Style with no attributes.
 func DefaultStyles(_, _ int) lipgloss.Style {
 	return lipgloss.NewStyle()
@@ -46,22 +50,37 @@ type Table struct {
 	borderLeft   bool
 	borderRight  bool
 	borderHeader bool
+	borderFooter bool
 	borderColumn bool
 	borderRow    bool
 
 	borderStyle lipgloss.Style
 	headers     []string
+	footers     []string
+	aggregates  map[int]aggregate
 	data        Data
+	columns     []Column
 
 	width  int
 	height int
 	offset int
 
+	sortIndicator bool
+
 	// widths tracks the width of each column.
 	widths []int
 
 	// heights tracks the height of each row.
 	heights []int
+
+	// headerSpans tracks the number of columns each header spans.
+	headerSpans []int
+
+	// spans tracks the cell covering each position of the table, if any cells
+	// span several rows or columns, and spanLines the rendered lines of the
+	// cells spanning several rows.
+	spans     [][]cellSpan
+	spanLines map[cellSpan][]string
 }
 
 // New returns a new Table that can be modified through different
@@ -74,6 +93,7 @@ func New() *Table {
 		border:       lipgloss.RoundedBorder(),
 		borderBottom: true,
 		borderColumn: true,
+		borderFooter: true,
 		borderHeader: true,
 		borderLeft:   true,
 		borderRight:  true,
@@ -97,9 +117,36 @@ func (t *Table) StyleFunc(style StyleFunc) *Table {
 // style returns the style for a cell based on it's position (row, column).
 func (t *Table) style(row, col int) lipgloss.Style {
 	if t.styleFunc == nil {
-		return lipgloss.NewStyle()
+		return t.column(col).style(lipgloss.NewStyle())
+	}
+	return t.column(col).style(t.styleFunc(row, col))
+}
+
+// Columns sets how the table's columns are sized and aligned, in order.
+// Columns without a specification are sized to fit their contents.
+func (t *Table) Columns(columns ...Column) *Table {
+	t.columns = columns
+	return t
+}
+
+// Column sets how the column at the given index is sized and aligned.
+func (t *Table) Column(index int, column Column) *Table {
+	if index < 0 {
+		return t
+	}
+	for len(t.columns) <= index {
+		t.columns = append(t.columns, NewColumn())
 	}
-	return t.styleFunc(row, col)
+	t.columns[index] = column
+	return t
+}
+
+// column returns the specification of the column at the given index.
+func (t *Table) column(index int) Column {
+	if index < 0 || index >= len(t.columns) {
+		return NewColumn()
+	}
+	return t.columns[index]
 }
 
 // Data sets the table data.
@@ -134,6 +181,69 @@ func (t *Table) Headers(headers ...string) *Table {
 	return t
 }
 
+// header returns the header of the given column, followed by an indicator of
+// the order it's sorted in, if it's sorted by and that's to be shown.
+func (t *Table) header(index int) string {
+	header := t.headers[index]
+	sorted, ok := t.data.(*Sorted)
+	if !t.sortIndicator || !ok {
+		return header
+	}
+	order, ok := sorted.Order(index)
+	if !ok {
+		return header
+	}
+
+	indicator := "▲"
+	if order == Descending {
+		indicator = "▼"
+	}
+	if header == "" {
+		return indicator
+	}
+	return header + " " + indicator
+}
+
+// SortIndicator sets whether to show the order of the columns the rows are
+// sorted by, with ▲ or ▼ after their headers, when the table's data is
+// Sorted.
+func (t *Table) SortIndicator(v bool) *Table {
+	t.sortIndicator = v
+	return t
+}
+
+// Footers sets the table footers, which are drawn below the rows, separated
+// from them like the headers are. The footer cells are styled with the
+// FooterRow row.
+func (t *Table) Footers(footers ...string) *Table {
+	t.footers = footers
+	return t
+}
+
+// Aggregate fills the footer cell of the given column with a summary of the
+// numeric cells in the column, such as their Sum. Cells that aren't numbers
+// are left out. format is a fmt verb to write the result with, such as
+// "%.2f"; if it's empty, the result is written as briefly as possible.
+//
+// Example:
+//
+//	t := table.New().
+//	    Headers("Item", "Amount").
+//	    Row("Seats", "120.50").
+//	    Row("Support", "9.99").
+//	    Footers("Total").
+//	    Aggregate(1, table.Sum, "%.2f")
+func (t *Table) Aggregate(col int, agg Aggregator, format string) *Table {
+	if col < 0 {
+		return t
+	}
+	if t.aggregates == nil {
+		t.aggregates = make(map[int]aggregate)
+	}
+	t.aggregates[col] = aggregate{fn: agg, format: format}
+	return t
+}
+
 // Border sets the table border.
 func (t *Table) Border(border lipgloss.Border) *Table {
 	t.border = border
@@ -170,6 +280,12 @@ func (t *Table) BorderHeader(v bool) *Table {
 	return t
 }
 
+// BorderFooter sets the footer separator border.
+func (t *Table) BorderFooter(v bool) *Table {
+	t.borderFooter = v
+	return t
+}
+
 // BorderColumn sets the column border separator.
 func (t *Table) BorderColumn(v bool) *Table {
 	t.borderColumn = v
@@ -210,38 +326,60 @@ func (t *Table) Offset(o int) *Table {
 
 // String returns the table as a string.
 func (t *Table) String() string {
+	var s strings.Builder
+	_, _ = t.WriteTo(&s)
+	return s.String()
+}
+
+// WriteTo writes the table to w a row at a time, rendering each row as it's
+// written rather than building the whole table up in memory first, which
+// helps with tables with a lot of rows. The cells are still measured up front
+// to size the columns. The output is the same as String's. It implements
+// io.WriterTo.
+func (t *Table) WriteTo(w io.Writer) (int64, error) {
 	hasHeaders := t.headers != nil && len(t.headers) > 0
 	hasRows := t.data != nil && t.data.Rows() > 0
+	hasFooters := len(t.footers) > 0 || len(t.aggregates) > 0
 
-	if !hasHeaders && !hasRows {
-		return ""
+	if !hasHeaders && !hasRows && !hasFooters {
+		return 0, nil
 	}
 
-	var s strings.Builder
+	// The table is as wide as the longest of the headers, the footers and the
+	// rows. Shorter ones are padded with empty cells.
+	columns := max(max(len(t.headers), len(t.footers)), t.data.Columns())
 
 	// Add empty cells to the headers, until it's the same length as the longest
 	// row (only if there are at headers in the first place).
 	if hasHeaders {
-		for i := len(t.headers); i < t.data.Columns(); i++ {
+		for i := len(t.headers); i < columns; i++ {
 			t.headers = append(t.headers, "")
 		}
 	}
 
 	// Initialize the widths.
-	t.widths = make([]int, max(len(t.headers), t.data.Columns()))
+	t.widths = make([]int, columns)
 	t.heights = make([]int, btoi(hasHeaders)+t.data.Rows())
+	t.layoutSpans(hasHeaders)
 
 	// The style function may affect width of the table. It's possible to set
 	// the StyleFunc after the headers and rows. Update the widths for a final
-	// time.
-	for i, cell := range t.headers {
+	// time. Cells spanning several columns or rows are fitted in afterwards.
+	for i := range t.headers {
+		if t.spanning(0, i) {
+			continue
+		}
+		cell := t.header(i)
 		t.widths[i] = max(t.widths[i], lipgloss.Width(t.style(0, i).Render(cell)))
 		t.heights[0] = max(t.heights[0], lipgloss.Height(t.style(0, i).Render(cell)))
 	}
 
 	for r := 0; r < t.data.Rows(); r++ {
-		for i := 0; i < t.data.Columns(); i++ {
-			cell := t.data.At(r, i)
+		for i := 0; i < columns; i++ {
+			if t.spanning(r+btoi(hasHeaders), i) {
+				continue
+			}
+			cell := t.at(r, i)
 
 			rendered := t.style(r+1, i).Render(cell)
 			t.heights[r+btoi(hasHeaders)] = max(t.heights[r+btoi(hasHeaders)], lipgloss.Height(rendered))
@@ -249,6 +387,16 @@ func (t *Table) String() string {
 		}
 	}
 
+	var footers []string
+	if hasFooters {
+		footers = t.footerCells(len(t.widths))
+		for i, cell := range footers {
+			t.widths[i] = max(t.widths[i], lipgloss.Width(t.style(FooterRow, i).Render(cell)))
+		}
+	}
+
+	t.fitSpans(hasHeaders)
+
 	// Table Resizing Logic.
 	//
 	// Given a user defined table width, we must ensure the table is exactly that
@@ -285,84 +433,178 @@ func (t *Table) String() string {
 	//
 	// The biggest difference is 15 - 2, so we can shrink the 2nd column by 13.
 
+	// Columns with a specification are kept within its constraints: fixed
+	// width columns and columns that mustn't shrink are left alone, and
+	// columns with a flex weight absorb the difference before any others.
+
+	for i := range t.widths {
+		t.widths[i] = t.column(i).constrain(t.widths[i])
+	}
+
 	width := t.computeWidth()
 
 	if width < t.width && t.width > 0 {
-		// Table is too narrow, expand the columns evenly until it reaches the
-		// desired width.
-		var i int
-		for width < t.width {
-			t.widths[i]++
-			width++
-			i = (i + 1) % len(t.widths)
-		}
+		t.growColumns(t.width - width)
 	} else if width > t.width && t.width > 0 {
-		// Table is too wide, calculate the median non-whitespace length of each
-		// column, and shrink the columns based on the largest difference.
-		columnMedians := make([]int, len(t.widths))
-		for c := range t.widths {
-			trimmedWidth := make([]int, t.data.Rows())
-			for r := 0; r < t.data.Rows(); r++ {
-				renderedCell := t.style(r+btoi(hasHeaders), c).Render(t.data.At(r, c))
-				nonWhitespaceChars := lipgloss.Width(strings.TrimRight(renderedCell, " "))
-				trimmedWidth[r] = nonWhitespaceChars + 1
-			}
+		t.shrinkColumns(width-t.width, hasHeaders)
+	}
 
-			columnMedians[c] = median(trimmedWidth)
-		}
+	// Like styled text, every line of the table is as wide as the widest one.
+	// The borders, headers and footers are put together up front to measure
+	// them, but the rows are only measured: they're rendered a row at a time
+	// as they're written out, stopping once the table is as tall as it can be.
+	var top, headers, footer, bottom string
+	if t.borderTop {
+		top = t.constructTopBorder()
+	}
+	if hasHeaders {
+		headers = t.constructHeaders()
+	}
+	if hasFooters {
+		footer = t.constructFooters(footers, hasHeaders || hasRows)
+	}
+	if t.borderBottom {
+		bottom = t.constructBottomBorder(hasFooters)
+	}
 
-		// Find the biggest differences between the median and the column width.
-		// Shrink the columns based on the largest difference.
-		differences := make([]int, len(t.widths))
-		for i := range t.widths {
-			differences[i] = t.widths[i] - columnMedians[i]
-		}
+	out := &lineWriter{
+		w:         w,
+		maxWidth:  t.width,
+		maxHeight: t.computeHeight(),
+	}
+	for _, piece := range []string{top, headers, footer, bottom} {
+		out.width = max(out.width, lipgloss.Width(piece))
+	}
+	out.width = max(out.width, t.rowsWidth(t.offset))
 
-		for width > t.width {
-			index, _ := largest(differences)
-			if differences[index] < 1 {
-				break
-			}
+	if t.borderTop {
+		out.WriteString(top + "\n")
+	}
 
-			shrink := min(differences[index], width-t.width)
-			t.widths[index] -= shrink
-			width -= shrink
-			differences[index] = 0
-		}
+	if hasHeaders {
+		out.WriteString(headers + "\n")
+	}
 
-		// Table is still too wide, begin shrinking the columns based on the
-		// largest column.
-		for width > t.width {
-			index, _ := largest(t.widths)
-			if t.widths[index] < 1 {
-				break
-			}
-			t.widths[index]--
-			width--
+	for r := t.offset; r < t.data.Rows() && !out.done(); r++ {
+		out.WriteString(t.constructRow(r))
+	}
+
+	if hasFooters && !out.done() {
+		out.WriteString(footer + "\n")
+	}
+
+	if t.borderBottom && !out.done() {
+		out.WriteString(bottom)
+	}
+
+	out.close()
+	return out.n, out.err
+}
+
+// growColumns widens the columns by n cells in total, as far as their
+// specifications allow. Flex columns are grown first; anything left over is
+// spread evenly across the other columns.
+func (t *Table) growColumns(n int) {
+	n = t.resizeFlexColumns(n, 1)
+
+	for i, skipped := 0, 0; n > 0 && skipped < len(t.widths); i = (i + 1) % len(t.widths) {
+		if !t.column(i).canGrow(t.widths[i]) {
+			skipped++
+			continue
 		}
+		t.widths[i]++
+		n--
+		skipped = 0
 	}
+}
 
-	if t.borderTop {
-		s.WriteString(t.constructTopBorder())
-		s.WriteString("\n")
+// shrinkColumns narrows the columns by n cells in total, as far as their
+// specifications allow. Flex columns are shrunk first; after that, the
+// columns with the most whitespace, as described in WriteTo, and finally the
+// widest columns.
+func (t *Table) shrinkColumns(n int, hasHeaders bool) {
+	n = t.resizeFlexColumns(n, -1)
+	if n <= 0 {
+		return
 	}
 
-	if hasHeaders {
-		s.WriteString(t.constructHeaders())
-		s.WriteString("\n")
+	// Calculate the median non-whitespace length of each column, and shrink
+	// the columns based on the largest difference.
+	differences := make([]int, len(t.widths))
+	for c := range t.widths {
+		column := t.column(c)
+		if !column.canShrink(t.widths[c]) {
+			continue
+		}
+
+		trimmedWidth := make([]int, t.data.Rows())
+		for r := 0; r < t.data.Rows(); r++ {
+			renderedCell := t.style(r+btoi(hasHeaders), c).Render(t.data.At(r, c))
+			nonWhitespaceChars := lipgloss.Width(strings.TrimRight(renderedCell, " "))
+			trimmedWidth[r] = nonWhitespaceChars + 1
+		}
+
+		differences[c] = min(t.widths[c]-median(trimmedWidth), t.widths[c]-column.minWidth)
 	}
 
-	for r := t.offset; r < t.data.Rows(); r++ {
-		s.WriteString(t.constructRow(r))
+	// Shrink the columns based on the largest difference.
+	for n > 0 {
+		index, _ := largest(differences)
+		if differences[index] < 1 {
+			break
+		}
+
+		shrink := min(differences[index], n)
+		t.widths[index] -= shrink
+		n -= shrink
+		differences[index] = 0
 	}
 
-	if t.borderBottom {
-		s.WriteString(t.constructBottomBorder())
+	// Table is still too wide, begin shrinking the columns based on the
+	// largest column.
+	for n > 0 {
+		index := -1
+		for i, w := range t.widths {
+			if t.column(i).canShrink(w) && (index < 0 || w > t.widths[index]) {
+				index = i
+			}
+		}
+		if index < 0 {
+			break
+		}
+		t.widths[index]--
+		n--
 	}
+}
 
-	return lipgloss.NewStyle().
-		MaxHeight(t.computeHeight()).
-		MaxWidth(t.width).Render(s.String())
+// resizeFlexColumns grows (dir 1) or shrinks (dir -1) the columns with a
+// flex weight by n cells in total, in proportion to their weights, returning
+// the number of cells left over once they can't be resized any further.
+func (t *Table) resizeFlexColumns(n, dir int) int {
+	resized := make([]int, len(t.widths))
+	for n > 0 {
+		// Resize the column that's been resized the least for its weight.
+		index := -1
+		for i, w := range t.widths {
+			column := t.column(i)
+			if column.flex == 0 {
+				continue
+			}
+			if dir > 0 && !column.canGrow(w) || dir < 0 && !column.canShrink(w) {
+				continue
+			}
+			if index < 0 || (resized[i]+1)*t.column(index).flex < (resized[index]+1)*column.flex {
+				index = i
+			}
+		}
+		if index < 0 {
+			break
+		}
+		t.widths[index] += dir
+		resized[index]++
+		n--
+	}
+	return n
 }
 
 // computeWidth computes the width of the table in it's current configuration.
@@ -377,9 +619,12 @@ func (t *Table) computeWidth() int {
 // computeHeight computes the height of the table in it's current configuration.
 func (t *Table) computeHeight() int {
 	hasHeaders := t.headers != nil && len(t.headers) > 0
+	hasFooters := len(t.footers) > 0 || len(t.aggregates) > 0
+	separator := t.borderFooter && (hasHeaders || t.data.Rows() > 0)
 	return sum(t.heights) - 1 + btoi(hasHeaders) +
 		btoi(t.borderTop) + btoi(t.borderBottom) +
-		btoi(t.borderHeader) + t.data.Rows()*btoi(t.borderRow)
+		btoi(t.borderHeader) + t.data.Rows()*btoi(t.borderRow) +
+		btoi(hasFooters)*(1+btoi(separator))
 }
 
 // Render returns the table as a string.
@@ -390,6 +635,12 @@ func (t *Table) Render() string {
 // constructTopBorder constructs the top border for the table given it's current
 // border configuration and data.
 func (t *Table) constructTopBorder() string {
+	hasHeaders := t.headers != nil && len(t.headers) > 0
+	first := 0
+	if !hasHeaders {
+		first = t.offset
+	}
+
 	var s strings.Builder
 	if t.borderLeft {
 		s.WriteString(t.borderStyle.Render(t.border.TopLeft))
@@ -397,7 +648,7 @@ func (t *Table) constructTopBorder() string {
 	for i := 0; i < len(t.widths); i++ {
 		s.WriteString(t.borderStyle.Render(strings.Repeat(t.border.Top, t.widths[i])))
 		if i < len(t.widths)-1 && t.borderColumn {
-			s.WriteString(t.borderStyle.Render(t.border.MiddleTop))
+			s.WriteString(t.borderStyle.Render(t.junction(false, !t.joined(first, i+1), true, true, t.border.Top)))
 		}
 	}
 	if t.borderRight {
@@ -408,7 +659,12 @@ func (t *Table) constructTopBorder() string {
 
 // constructBottomBorder constructs the bottom border for the table given it's current
 // border configuration and data.
-func (t *Table) constructBottomBorder() string {
+func (t *Table) constructBottomBorder(hasFooters bool) string {
+	last := len(t.heights) - 1
+	if hasFooters {
+		last = -1
+	}
+
 	var s strings.Builder
 	if t.borderLeft {
 		s.WriteString(t.borderStyle.Render(t.border.BottomLeft))
@@ -416,7 +672,7 @@ func (t *Table) constructBottomBorder() string {
 	for i := 0; i < len(t.widths); i++ {
 		s.WriteString(t.borderStyle.Render(strings.Repeat(t.border.Bottom, t.widths[i])))
 		if i < len(t.widths)-1 && t.borderColumn {
-			s.WriteString(t.borderStyle.Render(t.border.MiddleBottom))
+			s.WriteString(t.borderStyle.Render(t.junction(!t.joined(last, i+1), false, true, true, t.border.Bottom)))
 		}
 	}
 	if t.borderRight {
@@ -432,13 +688,17 @@ func (t *Table) constructHeaders() string {
 	if t.borderLeft {
 		s.WriteString(t.borderStyle.Render(t.border.Left))
 	}
-	for i, header := range t.headers {
+	for i := 0; i < len(t.headers); {
+		span := t.span(0, i)
+		width := t.spanWidth(i, span.cols)
 		s.WriteString(t.style(0, i).
 			MaxHeight(1).
-			Width(t.widths[i]).
-			MaxWidth(t.widths[i]).
-			Render(runewidth.Truncate(header, t.widths[i], "…")))
-		if i < len(t.headers)-1 && t.borderColumn {
+			Width(width).
+			MaxWidth(width).
+			Ellipsis("…").
+			Render(t.header(i)))
+		i += span.cols
+		if i < len(t.headers) && t.borderColumn {
 			s.WriteString(t.borderStyle.Render(t.border.Left))
 		}
 	}
@@ -453,7 +713,8 @@ func (t *Table) constructHeaders() string {
 		for i := 0; i < len(t.headers); i++ {
 			s.WriteString(t.borderStyle.Render(strings.Repeat(t.border.Top, t.widths[i])))
 			if i < len(t.headers)-1 && t.borderColumn {
-				s.WriteString(t.borderStyle.Render(t.border.Middle))
+				up, down := !t.joined(0, i+1), !t.joined(t.offset+1, i+1)
+				s.WriteString(t.borderStyle.Render(t.junction(up, down, true, true, t.border.Top)))
 			}
 		}
 		if t.borderRight {
@@ -466,13 +727,65 @@ func (t *Table) constructHeaders() string {
 	return s.String()
 }
 
+// constructFooters constructs the footers for the table, along with the
+// separator above them, given it's current footer configuration and data.
+// The separator is left out when there's nothing above the footers to
+// separate them from.
+func (t *Table) constructFooters(footers []string, separate bool) string {
+	var s strings.Builder
+	if t.borderFooter && separate {
+		if t.borderLeft {
+			s.WriteString(t.borderStyle.Render(t.border.MiddleLeft))
+		}
+		last := len(t.heights) - 1
+		for i := 0; i < len(footers); i++ {
+			s.WriteString(t.borderStyle.Render(strings.Repeat(t.border.Top, t.widths[i])))
+			if i < len(footers)-1 && t.borderColumn {
+				s.WriteString(t.borderStyle.Render(t.junction(!t.joined(last, i+1), true, true, true, t.border.Top)))
+			}
+		}
+		if t.borderRight {
+			s.WriteString(t.borderStyle.Render(t.border.MiddleRight))
+		}
+		s.WriteString("\n")
+	}
+	if t.borderLeft {
+		s.WriteString(t.borderStyle.Render(t.border.Left))
+	}
+	for i, footer := range footers {
+		s.WriteString(t.style(FooterRow, i).
+			MaxHeight(1).
+			Width(t.widths[i]).
+			MaxWidth(t.widths[i]).
+			Ellipsis("…").
+			Render(footer))
+		if i < len(footers)-1 && t.borderColumn {
+			s.WriteString(t.borderStyle.Render(t.border.Left))
+		}
+	}
+	if t.borderRight {
+		s.WriteString(t.borderStyle.Render(t.border.Right))
+	}
+	return s.String()
+}
+
+// at returns the contents of the cell at the given index, or an empty cell
+// past the end of the data's columns.
+func (t *Table) at(row, col int) string {
+	if col >= t.data.Columns() {
+		return ""
+	}
+	return t.data.At(row, col)
+}
+
 // constructRow constructs the row for the table given an index and row data
 // based on the current configuration.
 func (t *Table) constructRow(index int) string {
 	var s strings.Builder
 
 	hasHeaders := t.headers != nil && len(t.headers) > 0
-	height := t.heights[index+btoi(hasHeaders)]
+	row := index + btoi(hasHeaders)
+	height := t.heights[row]
 
 	var cells []string
 	left := strings.Repeat(t.borderStyle.Render(t.border.Left)+"\n", height)
@@ -480,17 +793,12 @@ func (t *Table) constructRow(index int) string {
 		cells = append(cells, left)
 	}
 
-	for c := 0; c < t.data.Columns(); c++ {
-		cell := t.data.At(index, c)
-
-		cells = append(cells, t.style(index+1, c).
-			Height(height).
-			MaxHeight(height).
-			Width(t.widths[c]).
-			MaxWidth(t.widths[c]).
-			Render(runewidth.Truncate(cell, t.widths[c]*height, "…")))
+	for c := 0; c < len(t.widths); {
+		span := t.span(row, c)
+		cells = append(cells, t.constructCell(index, c))
 
-		if c < t.data.Columns()-1 && t.borderColumn {
+		c += span.cols
+		if c < len(t.widths) && t.borderColumn {
 			cells = append(cells, left)
 		}
 	}
@@ -507,15 +815,105 @@ func (t *Table) constructRow(index int) string {
 	s.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, cells...) + "\n")
 
 	if t.borderRow && index < t.data.Rows()-1 {
-		s.WriteString(t.borderStyle.Render(t.border.MiddleLeft))
-		for i := 0; i < len(t.widths); i++ {
-			s.WriteString(t.borderStyle.Render(strings.Repeat(t.border.Bottom, t.widths[i])))
-			if i < len(t.widths)-1 && t.borderColumn {
-				s.WriteString(t.borderStyle.Render(t.border.Middle))
-			}
+		s.WriteString(t.constructRowSeparator(row) + "\n")
+	}
+
+	return s.String()
+}
+
+// constructCell constructs the lines of the cell at the given index of the
+// row, which is as tall as the row.
+func (t *Table) constructCell(index, col int) string {
+	hasHeaders := t.headers != nil && len(t.headers) > 0
+	row := index + btoi(hasHeaders)
+	height := t.heights[row]
+
+	span := t.span(row, col)
+	if span.rows > 1 {
+		// Cells spanning several rows are rendered as a whole and handed out
+		// a row at a time.
+		y := sum(t.heights[span.row:row]) + (row-span.row)*btoi(t.borderRow)
+		lines := make([]string, height)
+		for i := range lines {
+			lines[i] = t.spanLine(span, y+i)
 		}
-		s.WriteString(t.borderStyle.Render(t.border.MiddleRight) + "\n")
+		return strings.Join(lines, "\n")
 	}
 
+	width := t.spanWidth(col, span.cols)
+	return t.style(index+1, col).
+		Height(height).
+		MaxHeight(height).
+		Width(width).
+		MaxWidth(width).
+		Ellipsis("…").
+		Render(t.at(index, col))
+}
+
+// constructRowSeparator constructs the separator below the given row of the
+// grid. Cells spanning into the next row carry on across it.
+func (t *Table) constructRowSeparator(row int) string {
+	var s strings.Builder
+	last := len(t.widths) - 1
+	s.WriteString(t.borderStyle.Render(t.junction(true, true, false, !t.continues(row, 0), t.border.Bottom)))
+	for i := 0; i <= last; {
+		if t.continues(row, i) {
+			span := t.span(row, i)
+			s.WriteString(t.spanLine(span, t.spanHeight(span.row, row-span.row+1)))
+			i += span.cols
+		} else {
+			s.WriteString(t.borderStyle.Render(strings.Repeat(t.border.Bottom, t.widths[i])))
+			i++
+		}
+		if i <= last && t.borderColumn {
+			up, down := !t.joined(row, i), !t.joined(row+1, i)
+			left, right := !t.continues(row, i-1), !t.continues(row, i)
+			s.WriteString(t.borderStyle.Render(t.junction(up, down, left, right, t.border.Bottom)))
+		}
+	}
+	s.WriteString(t.borderStyle.Render(t.junction(true, true, !t.continues(row, last), false, t.border.Bottom)))
 	return s.String()
 }
+
+// rowsWidth works out how wide the lines of the rows from the given index on
+// are, without rendering any more of them than it needs to. The lines of a
+// row are as wide as its cells and borders add up to, and unless cells span
+// or columns are left with no width, every row is as wide as the first.
+func (t *Table) rowsWidth(first int) int {
+	hasHeaders := t.headers != nil && len(t.headers) > 0
+	left := lipgloss.Width(t.borderStyle.Render(t.border.Left))
+	right := lipgloss.Width(t.borderStyle.Render(t.border.Right))
+
+	same := t.spans == nil
+	for _, w := range t.widths {
+		same = same && w > 0
+	}
+
+	var width int
+	for index := first; index < t.data.Rows(); index++ {
+		row := index + btoi(hasHeaders)
+		w := btoi(t.borderLeft)*left + btoi(t.borderRight)*right
+		for c := 0; c < len(t.widths); {
+			span := t.span(row, c)
+			if cw := t.spanWidth(c, span.cols); cw > 0 {
+				w += cw
+			} else {
+				// A cell with no width set is as wide as what's in it.
+				w += lipgloss.Width(t.constructCell(index, c))
+			}
+			c += span.cols
+			if c < len(t.widths) && t.borderColumn {
+				w += left
+			}
+		}
+		width = max(width, w)
+
+		if t.borderRow && index < t.data.Rows()-1 {
+			width = max(width, lipgloss.Width(t.constructRowSeparator(row)))
+		}
+		if same {
+			break
+		}
+	}
+	return width
+}
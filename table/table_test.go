@@ -1,8 +1,10 @@
 package table
 
 import (
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -692,13 +694,13 @@ func TestTableWidthShrink(t *testing.T) {
 
 	expected := strings.TrimSpace(`
 ┌─────────┬─────────┬────────┐
-│ LANGUAG │ FORMAL  │ INFORM │
+│ LANGUA… │ FORMAL  │ INFOR… │
 ├─────────┼─────────┼────────┤
 │ Chinese │ Nǐn hǎo │ Nǐ hǎo │
 │ French  │ Bonjour │ Salut  │
-│ Japanes │ こんに  │ やあ   │
-│ Russian │ Zdravst │ Privet │
-│ Spanish │ Hola    │ ¿Qué   │
+│ Japane… │ こんに… │ やあ   │
+│ Russian │ Zdravs… │ Privet │
+│ Spanish │ Hola    │ ¿Qué…  │
 └─────────┴─────────┴────────┘
 `)
 
@@ -723,7 +725,7 @@ func TestTableWidthSmartCrop(t *testing.T) {
 
 	expected := strings.TrimSpace(`
 ┌──────┬─────┬──────────┐
-│ Name │ Age │ Location │
+│ Name │ Ag… │ Location │
 ├──────┼─────┼──────────┤
 │ Kini │ 40  │ New York │
 │ Eli  │ 30  │ London   │
@@ -755,14 +757,14 @@ func TestTableWidthSmartCropExtensive(t *testing.T) {
 
 	expected := strings.TrimSpace(`
 ┏━━━━┳━━━━━┳━━━━━┓
-┃ LA ┃ FOR ┃ INF ┃
+┃ L… ┃ FO… ┃ IN… ┃
 ┣━━━━╋━━━━━╋━━━━━┫
-┃ Ch ┃ 您  ┃ 你  ┃
-┃ Ja ┃ こ  ┃ や  ┃
-┃ Ar ┃ أهل ┃ أهل ┃
-┃ Ru ┃ Здр ┃ При ┃
-┃ Sp ┃ Hol ┃ ¿Qu ┃
-┃ En ┃ You ┃ How ┃
+┃ C… ┃ 您… ┃ 你… ┃
+┃ J… ┃ こ… ┃ や… ┃
+┃ A… ┃ أه… ┃ أه… ┃
+┃ R… ┃ Зд… ┃ Пр… ┃
+┃ S… ┃ Ho… ┃ ¿Q… ┃
+┃ E… ┃ Yo… ┃ Ho… ┃
 ┗━━━━┻━━━━━┻━━━━━┛
 `)
 
@@ -829,8 +831,8 @@ func TestTableWidths(t *testing.T) {
 ──────────────────────────────
  Chinese   Nǐn hǎo  Nǐ hǎo    
  French    Bonjour  Salut     
- Japanese  こんに   やあ      
- Russian   Zdravst  Privet    
+ Japanese  こんに…  やあ      
+ Russian   Zdravs…  Privet    
  Spanish   Hola     ¿Qué tal? 
 ──────────────────────────────
 `)
@@ -865,8 +867,8 @@ func TestTableWidthShrinkNoBorders(t *testing.T) {
 ──────────────────────────────
  Chinese   Nǐn hǎo  Nǐ hǎo    
  French    Bonjour  Salut     
- Japanese  こんに   やあ      
- Russian   Zdravst  Privet    
+ Japanese  こんに…  やあ      
+ Russian   Zdravs…  Privet    
  Spanish   Hola     ¿Qué tal? 
 ──────────────────────────────
 `)
@@ -876,6 +878,319 @@ func TestTableWidthShrinkNoBorders(t *testing.T) {
 	}
 }
 
+func TestTableColumns(t *testing.T) {
+	rows := [][]string{
+		{"1001", "Monthly subscription for the team plan", "49.00"},
+		{"1002", "Extra seats", "120.50"},
+		{"1003", "Support add-on for priority response", "9.99"},
+	}
+
+	table := New().
+		Width(40).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			return lipgloss.NewStyle().Padding(0, 1)
+		}).
+		Border(lipgloss.NormalBorder()).
+		Headers("ID", "DESCRIPTION", "AMOUNT").
+		Rows(rows...).
+		Columns(
+			NewColumn().NoShrink(true),
+			NewColumn().Flex(1),
+			NewColumn().Align(lipgloss.Right),
+		)
+
+	expected := strings.TrimSpace(`
+┌──────┬──────────────────────┬────────┐
+│ ID   │ DESCRIPTION          │ AMOUNT │
+├──────┼──────────────────────┼────────┤
+│ 1001 │ Monthly subscriptio… │  49.00 │
+│ 1002 │ Extra seats          │ 120.50 │
+│ 1003 │ Support add-on for…  │   9.99 │
+└──────┴──────────────────────┴────────┘
+`)
+
+	if table.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, table.String())
+	}
+
+	table.Width(60)
+
+	expected = strings.TrimSpace(`
+┌──────┬──────────────────────────────────────────┬────────┐
+│ ID   │ DESCRIPTION                              │ AMOUNT │
+├──────┼──────────────────────────────────────────┼────────┤
+│ 1001 │ Monthly subscription for the team plan   │  49.00 │
+│ 1002 │ Extra seats                              │ 120.50 │
+│ 1003 │ Support add-on for priority response     │   9.99 │
+└──────┴──────────────────────────────────────────┴────────┘
+`)
+
+	if table.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, table.String())
+	}
+}
+
+func TestTableColumnConstraints(t *testing.T) {
+	table := New().
+		StyleFunc(func(row, col int) lipgloss.Style {
+			return lipgloss.NewStyle().Padding(0, 1)
+		}).
+		Border(lipgloss.NormalBorder()).
+		Headers("ID", "DESCRIPTION", "AMOUNT").
+		Row("1001", "Extra seats for the team", "120.50").
+		Row("1002", "Support", "9.99").
+		Column(0, NewColumn().Width(4)).
+		Column(1, NewColumn().MaxWidth(16)).
+		Column(2, NewColumn().MinWidth(10).Align(lipgloss.Right))
+
+	expected := strings.TrimSpace(`
+┌────┬────────────────┬──────────┐
+│ ID │ DESCRIPTION    │   AMOUNT │
+├────┼────────────────┼──────────┤
+│ 1… │ Extra seats…   │   120.50 │
+│ 1… │ Support        │     9.99 │
+└────┴────────────────┴──────────┘
+`)
+
+	if table.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, table.String())
+	}
+}
+
+func TestTableColumnFlex(t *testing.T) {
+	table := New().
+		Width(50).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			return lipgloss.NewStyle().Padding(0, 1)
+		}).
+		Border(lipgloss.NormalBorder()).
+		Headers("A", "B", "C").
+		Row("x", "y", "z").
+		Columns(NewColumn().Flex(1), NewColumn().Flex(2))
+
+	expected := strings.TrimSpace(`
+┌───────────────┬────────────────────────────┬───┐
+│ A             │ B                          │ C │
+├───────────────┼────────────────────────────┼───┤
+│ x             │ y                          │ z │
+└───────────────┴────────────────────────────┴───┘
+`)
+
+	if table.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, table.String())
+	}
+}
+
+func TestTableHeaderSpan(t *testing.T) {
+	table := New().
+		Border(lipgloss.NormalBorder()).
+		StyleFunc(TableStyle).
+		Headers("", "Q1", "", "", "Q2").
+		HeaderSpan(1, 3).
+		HeaderSpan(4, 3).
+		Row("Region", "Jan", "Feb", "Mar", "Apr", "May", "Jun").
+		Row("North", "12", "15", "9", "20", "18", "22").
+		Row("South", "8", "11", "14", "7", "10", "13")
+
+	expected := strings.TrimSpace(`
+┌────────┬─────────────────┬─────────────────┐
+│        │       Q1        │       Q2        │
+├────────┼─────┬─────┬─────┼─────┬─────┬─────┤
+│ Region │ Jan │ Feb │ Mar │ Apr │ May │ Jun │
+│ North  │ 12  │ 15  │ 9   │ 20  │ 18  │ 22  │
+│ South  │ 8   │ 11  │ 14  │ 7   │ 10  │ 13  │
+└────────┴─────┴─────┴─────┴─────┴─────┴─────┘
+`)
+
+	if table.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, table.String())
+	}
+}
+
+func TestTableSpan(t *testing.T) {
+	data := NewStringData(
+		[]string{"Fruit", "Apple", "Red"},
+		[]string{"", "Banana", "Yellow"},
+		[]string{"Vegetable", "Out of season", ""},
+	).
+		SetSpan(0, 0, 2, 1).
+		SetSpan(2, 1, 1, 2)
+
+	table := New().
+		Border(lipgloss.NormalBorder()).
+		BorderRow(true).
+		StyleFunc(TableStyle).
+		Headers("KIND", "NAME", "COLOR").
+		Data(data)
+
+	expected := strings.TrimSpace(`
+┌───────────┬────────┬────────┐
+│   KIND    │  NAME  │ COLOR  │
+├───────────┼────────┼────────┤
+│ Fruit     │ Apple  │ Red    │
+│           ├────────┼────────┤
+│           │ Banana │ Yellow │
+├───────────┼────────┴────────┤
+│ Vegetable │ Out of season   │
+└───────────┴─────────────────┘
+`)
+
+	if table.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, table.String())
+	}
+}
+
+func TestTableSpanWidens(t *testing.T) {
+	data := NewStringData(
+		[]string{"a", "b"},
+		[]string{"a much longer cell", ""},
+	).SetSpan(1, 0, 1, 2)
+
+	table := New().
+		Border(lipgloss.NormalBorder()).
+		Data(data)
+
+	expected := strings.TrimSpace(`
+┌─────────┬────────┐
+│a        │b       │
+│a much longer cell│
+└──────────────────┘
+`)
+
+	if table.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, table.String())
+	}
+}
+
+func TestTableFooters(t *testing.T) {
+	table := New().
+		Border(lipgloss.NormalBorder()).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			style := lipgloss.NewStyle().Padding(0, 1)
+			if row == FooterRow {
+				style = style.Bold(true)
+			}
+			if col == 1 {
+				style = style.Align(lipgloss.Right)
+			}
+			return style
+		}).
+		Headers("ITEM", "AMOUNT").
+		Row("Extra seats", "120.50").
+		Row("Support", "9.99").
+		Row("Credit", "-5").
+		Footers("Total").
+		Aggregate(1, Sum, "%.2f")
+
+	expected := strings.TrimSpace(`
+┌─────────────┬────────┐
+│ ITEM        │ AMOUNT │
+├─────────────┼────────┤
+│ Extra seats │ 120.50 │
+│ Support     │   9.99 │
+│ Credit      │     -5 │
+├─────────────┼────────┤
+│ Total       │ 125.49 │
+└─────────────┴────────┘
+`)
+
+	if table.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, table.String())
+	}
+}
+
+func TestTableFooterSpans(t *testing.T) {
+	data := NewStringData(
+		[]string{"a", "1", "2"},
+		[]string{"spanning", "", ""},
+	).SetSpan(1, 0, 1, 3)
+
+	table := New().
+		Border(lipgloss.NormalBorder()).
+		Data(data).
+		Footers("n").
+		Aggregate(1, Count, "").
+		Aggregate(2, Max, "")
+
+	expected := strings.TrimSpace(`
+┌──┬──┬──┐
+│a │1 │2 │
+│spanning│
+├──┬──┬──┤
+│n │1 │2 │
+└──┴──┴──┘
+`)
+
+	if table.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, table.String())
+	}
+}
+
+func TestTableFootersWiderThanRows(t *testing.T) {
+	table := New().
+		Border(lipgloss.NormalBorder()).
+		Headers("a").
+		Row("1").
+		Footers("f", "g", "h")
+
+	expected := strings.TrimSpace(`
+┌─┬─┬─┐
+│a│ │ │
+├─┼─┼─┤
+│1│ │ │
+├─┼─┼─┤
+│f│g│h│
+└─┴─┴─┘
+`)
+
+	if table.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, table.String())
+	}
+}
+
+func TestTableFootersOnly(t *testing.T) {
+	table := New().
+		Border(lipgloss.NormalBorder()).
+		Footers("a", "b")
+
+	expected := strings.TrimSpace(`
+┌─┬─┐
+│a│b│
+└─┴─┘
+`)
+
+	if table.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, table.String())
+	}
+}
+
+func TestAggregators(t *testing.T) {
+	values := []float64{3, -1, 4, 1.5}
+	tests := []struct {
+		name     string
+		agg      Aggregator
+		values   []float64
+		expected float64
+	}{
+		{"Sum", Sum, values, 7.5},
+		{"Avg", Avg, values, 1.875},
+		{"Count", Count, values, 4},
+		{"Min", Min, values, -1},
+		{"Max", Max, values, 4},
+		{"Sum of none", Sum, nil, 0},
+		{"Avg of none", Avg, nil, 0},
+		{"Count of none", Count, nil, 0},
+		{"Min of none", Min, nil, 0},
+		{"Max of none", Max, nil, 0},
+	}
+
+	for _, tc := range tests {
+		if got := tc.agg(tc.values); got != tc.expected {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, got)
+		}
+	}
+}
+
 func TestFilter(t *testing.T) {
 	data := NewStringData().
 		Item("Chinese", "Nǐn hǎo", "Nǐ hǎo").
@@ -941,6 +1256,336 @@ func TestFilterInverse(t *testing.T) {
 	}
 }
 
+func TestFilterIndex(t *testing.T) {
+	data := NewStringData().
+		Item("Chinese", "Nǐn hǎo", "Nǐ hǎo").
+		Item("French", "Bonjour", "Salut").
+		Item("Japanese", "こんにちは", "やあ").
+		Item("Russian", "Zdravstvuyte", "Privet")
+
+	calls := 0
+	prefix := "J"
+	filter := NewFilter(data).Filter(func(row int) bool {
+		calls++
+		return !strings.HasPrefix(data.At(row, 0), prefix)
+	})
+
+	if rows := filter.Rows(); rows != 3 {
+		t.Fatalf("expected 3 rows, got %d", rows)
+	}
+	for row, expected := range []int{0, 1, 3, -1} {
+		if i := filter.Index(row); i != expected {
+			t.Errorf("expected row %d to be row %d of the data, got %d", row, expected, i)
+		}
+	}
+	_ = New().Data(filter).String()
+	if calls != data.Rows() {
+		t.Errorf("expected the filter to be applied once to each row, got %d calls", calls)
+	}
+
+	// Adding rows to the data brings the filter up to date.
+	data.Item("Spanish", "Hola", "¿Qué tal?")
+	if got := filter.At(3, 0); got != "Spanish" {
+		t.Errorf("expected Spanish, got %q", got)
+	}
+
+	// So does setting the filter again.
+	prefix = "C"
+	filter.Filter(filter.filter)
+	if got := filter.At(0, 0); got != "French" {
+		t.Errorf("expected French, got %q", got)
+	}
+}
+
+func TestFilterSorted(t *testing.T) {
+	data := NewStringData().
+		Item("keep-b", "1").
+		Item("drop", "2").
+		Item("keep-a", "3")
+
+	sorted := NewSorted(data).Sort(SortKey{Column: 1, Order: Descending})
+	filter := NewFilter(sorted).Filter(func(row int) bool {
+		return strings.HasPrefix(sorted.At(row, 0), "keep")
+	})
+
+	rows := func() string {
+		var names []string
+		for r := 0; r < filter.Rows(); r++ {
+			names = append(names, filter.At(r, 0))
+		}
+		return strings.Join(names, " ")
+	}
+
+	if got := rows(); got != "keep-a keep-b" {
+		t.Errorf("expected keep-a keep-b, got %s", got)
+	}
+
+	// Sorting the data again changes the rows the filter matches, though not
+	// how many there are.
+	sorted.Sort(SortKey{Column: 0})
+	if got := rows(); got != "keep-a keep-b" {
+		t.Errorf("after sorting again, expected keep-a keep-b, got %s", got)
+	}
+
+	// So does changing a cell.
+	data.Set(1, 0, "keep-c")
+	data.Set(0, 0, "drop")
+	if got := rows(); got != "keep-a keep-c" {
+		t.Errorf("after changing cells, expected keep-a keep-c, got %s", got)
+	}
+}
+
+func TestSorted(t *testing.T) {
+	data := NewStringData().
+		Item("nginx", "1.25.3", "12").
+		Item("bash", "5.2", "3").
+		Item("curl", "8.4.0", "12").
+		Item("zlib", "1.3", "2").
+		Item("git", "2.43.0-rc.1", "12")
+
+	sorted := NewSorted(data).Sort(
+		SortKey{Column: 2, Order: Descending, Compare: CompareNumbers},
+		SortKey{Column: 1, Compare: CompareSemver},
+	)
+
+	table := New().
+		Border(lipgloss.NormalBorder()).
+		StyleFunc(TableStyle).
+		Headers("PACKAGE", "VERSION", "DEPS").
+		SortIndicator(true).
+		Data(sorted)
+
+	expected := strings.TrimSpace(`
+┌─────────┬─────────────┬────────┐
+│ PACKAGE │  VERSION ▲  │ DEPS ▼ │
+├─────────┼─────────────┼────────┤
+│ nginx   │ 1.25.3      │ 12     │
+│ git     │ 2.43.0-rc.1 │ 12     │
+│ curl    │ 8.4.0       │ 12     │
+│ bash    │ 5.2         │ 3      │
+│ zlib    │ 1.3         │ 2      │
+└─────────┴─────────────┴────────┘
+`)
+
+	if table.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, table.String())
+	}
+
+	if i := sorted.Index(1); i != 4 {
+		t.Errorf("expected row 1 to be row 4 of the data, got %d", i)
+	}
+
+	data.Item("awk", "1.0", "0")
+	sorted.Sort(SortKey{Column: 0})
+	if got := sorted.At(0, 0); got != "awk" {
+		t.Errorf("expected awk to sort first, got %s", got)
+	}
+}
+
+func TestSortedUnparsed(t *testing.T) {
+	data := NewStringData().
+		Item("a", "2").
+		Item("b", "N/A").
+		Item("c", "10").
+		Item("d", "").
+		Item("e", "-1")
+
+	// Cells that aren't numbers sort last either way.
+	tests := []struct {
+		order    SortOrder
+		expected string
+	}{
+		{Ascending, "eacdb"},
+		{Descending, "caebd"},
+	}
+	for _, tc := range tests {
+		sorted := NewSorted(data).Sort(SortKey{Column: 1, Order: tc.order, Compare: CompareNumbers})
+		var got string
+		for r := 0; r < sorted.Rows(); r++ {
+			got += sorted.At(r, 0)
+		}
+		if got != tc.expected {
+			t.Errorf("order %d: expected %s, got %s", tc.order, tc.expected, got)
+		}
+	}
+}
+
+func TestSortedSpans(t *testing.T) {
+	data := NewStringData(
+		[]string{"b", "2", "x"},
+		[]string{"total", "", ""},
+		[]string{"a", "1", "y"},
+	).SetSpan(1, 0, 2, 3)
+
+	table := New().
+		Border(lipgloss.NormalBorder()).
+		Data(NewSorted(data).Sort(SortKey{Column: 0}))
+
+	expected := strings.TrimSpace(`
+┌─┬─┬─┐
+│a│1│y│
+│b│2│x│
+│total│
+└─────┘
+`)
+
+	if table.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, table.String())
+	}
+
+	filter := NewFilter(data).Filter(func(row int) bool { return row > 0 })
+	if rows, columns := filter.Span(0, 0); rows != 1 || columns != 3 {
+		t.Errorf("expected the filtered cell to span 1 row and 3 columns, got %d and %d", rows, columns)
+	}
+}
+
+func TestComparators(t *testing.T) {
+	tests := []struct {
+		name     string
+		compare  Comparator
+		a, b     string
+		expected int
+	}{
+		{"strings", CompareStrings, "b", "a", 1},
+		{"strings", CompareStrings, "file10", "file2", -1},
+		{"natural", CompareNatural, "file10", "file2", 1},
+		{"natural", CompareNatural, "file02", "file2", 0},
+		{"natural", CompareNatural, "a1b", "a1", 1},
+		{"natural", CompareNatural, "abc", "abd", -1},
+		{"numbers", CompareNumbers, "10", "9.5", 1},
+		{"numbers", CompareNumbers, " -3 ", "2", -1},
+		{"numbers", CompareNumbers, "n/a", "2", 1},
+		{"numbers", CompareNumbers, "1e3", "1000", 0},
+		{"times", CompareTimes("2006-01-02"), "2024-01-02", "2023-12-31", 1},
+		{"times", CompareTimes(time.Kitchen), "9:00AM", "3:04PM", -1},
+		{"times", CompareTimes(time.Kitchen), "later", "3:04PM", 1},
+		{"semver", CompareSemver, "v1.10.0", "v1.9.0", 1},
+		{"semver", CompareSemver, "1.0.0-rc.1", "1.0.0", -1},
+		{"semver", CompareSemver, "1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"semver", CompareSemver, "1.0.0-alpha.beta", "1.0.0-alpha.1", 1},
+		{"semver", CompareSemver, "1.0.0-rc.2", "1.0.0-rc.10", -1},
+		{"semver", CompareSemver, "1.2", "1.2.0+build.5", 0},
+		{"semver", CompareSemver, "latest", "1.2.0", 1},
+	}
+
+	for _, tc := range tests {
+		got := tc.compare(tc.a, tc.b)
+		if got < 0 {
+			got = -1
+		} else if got > 0 {
+			got = 1
+		}
+		if got != tc.expected {
+			t.Errorf("%s: expected %q compared to %q to be %d, got %d", tc.name, tc.a, tc.b, tc.expected, got)
+		}
+	}
+}
+
+// writeCounter counts the writes made to it.
+type writeCounter struct {
+	b      strings.Builder
+	writes int
+}
+
+func (w *writeCounter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.b.Write(p)
+}
+
+func TestTableWriteTo(t *testing.T) {
+	tt := []struct {
+		table    *Table
+		expected string
+	}{
+		{New(), ""},
+		{
+			New().
+				Border(lipgloss.NormalBorder()).
+				StyleFunc(TableStyle).
+				Headers("LANGUAGE", "FORMAL", "INFORMAL").
+				Row("Chinese", "Nǐn hǎo", "Nǐ hǎo").
+				Row("French", "Bonjour", "Salut"),
+			strings.TrimSpace(`
+┌──────────┬─────────┬──────────┐
+│ LANGUAGE │ FORMAL  │ INFORMAL │
+├──────────┼─────────┼──────────┤
+│ Chinese  │ Nǐn hǎo │ Nǐ hǎo   │
+│ French   │ Bonjour │ Salut    │
+└──────────┴─────────┴──────────┘
+`),
+		},
+		{
+			New().
+				Border(lipgloss.HiddenBorder()).
+				BorderLeft(false).
+				BorderRow(true).
+				Width(20).
+				Height(4).
+				Rows([]string{"Japanese", "こんにちは\nやあ"}, []string{"Russian", "Zdravstvuyte"}),
+			"                    \n" +
+				"Japanese こんにちは \n" +
+				"         やあ       \n" +
+				"                    \n" +
+				"Russian  Zdravstvu… \n" +
+				"                    ",
+		},
+	}
+
+	for i, tc := range tt {
+		var w writeCounter
+		n, err := tc.table.WriteTo(&w)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i, err)
+		}
+		if w.b.String() != tc.expected {
+			t.Errorf("Test %d, expected:\n\n%s\n\nGot:\n\n%s", i, tc.expected, w.b.String())
+		}
+		if n != int64(len(tc.expected)) {
+			t.Errorf("Test %d, expected %d bytes written, got %d", i, len(tc.expected), n)
+		}
+
+		// The table is written out a line at a time.
+		if lines := strings.Count(tc.expected, "\n") + btoi(tc.expected != ""); w.writes != lines {
+			t.Errorf("Test %d, expected %d writes, got %d", i, lines, w.writes)
+		}
+	}
+}
+
+func BenchmarkTableString(b *testing.B) {
+	t := New().
+		Border(lipgloss.NormalBorder()).
+		StyleFunc(TableStyle).
+		Headers("ID", "NAME", "EMAIL", "ROLE")
+	for i := 0; i < 100; i++ {
+		t.Row(fmt.Sprint(i), "Name", "name@example.com", "Developer")
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = t.String()
+	}
+}
+
+func BenchmarkTableFilter(b *testing.B) {
+	data := NewStringData()
+	for i := 0; i < 1000; i++ {
+		data.Append([]string{fmt.Sprint(i), "Name", "name@example.com", "Developer"})
+	}
+	filter := NewFilter(data).Filter(func(row int) bool {
+		return row%2 == 0
+	})
+	t := New().
+		Border(lipgloss.NormalBorder()).
+		StyleFunc(TableStyle).
+		Headers("ID", "NAME", "EMAIL", "ROLE").
+		Data(filter)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = t.String()
+	}
+}
+
 func debug(s string) string {
 	return strings.ReplaceAll(s, " ", ".")
 }
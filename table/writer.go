@@ -0,0 +1,79 @@
+package table
+
+import (
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// lineWriter writes the lines of a table as they're put together. Like
+// rendering the table as a whole with a style, it pads lines to the width of
+// the table and cuts them down to the maximum width and height.
+type lineWriter struct {
+	w io.Writer
+
+	width               int
+	maxWidth, maxHeight int
+
+	line  strings.Builder
+	lines int
+
+	n   int64
+	err error
+}
+
+// WriteString writes a piece of the table, which may span several lines.
+func (l *lineWriter) WriteString(str string) {
+	for {
+		i := strings.IndexByte(str, '\n')
+		if i < 0 {
+			l.line.WriteString(str)
+			return
+		}
+		l.line.WriteString(str[:i])
+		l.endLine()
+		str = str[i+1:]
+	}
+}
+
+// endLine writes out the line being written.
+func (l *lineWriter) endLine() {
+	line := strings.ReplaceAll(l.line.String(), "\t", "    ")
+	l.line.Reset()
+
+	if l.maxHeight > 0 && l.lines >= l.maxHeight {
+		return
+	}
+	if short := l.width - lipgloss.Width(line); short > 0 {
+		line += strings.Repeat(" ", short)
+	}
+	if l.maxWidth > 0 {
+		line = lipgloss.NewStyle().MaxWidth(l.maxWidth).Render(line)
+	}
+	if l.lines > 0 {
+		line = "\n" + line
+	}
+	l.write(line)
+	l.lines++
+}
+
+// done reports whether there's no more to write, because the table is as
+// tall as it can be or writing failed.
+func (l *lineWriter) done() bool {
+	return l.err != nil || l.maxHeight > 0 && l.lines >= l.maxHeight
+}
+
+// close writes out the last line.
+func (l *lineWriter) close() {
+	l.endLine()
+}
+
+func (l *lineWriter) write(str string) {
+	if l.err != nil {
+		return
+	}
+	n, err := io.WriteString(l.w, str)
+	l.n += int64(n)
+	l.err = err
+}
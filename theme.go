@@ -0,0 +1,84 @@
+package lipgloss
+
+import "github.com/muesli/termenv"
+
+// Theme maps semantic color names, such as "primary", "muted" or "error", to
+// colors. Styles refer to these colors with ThemeColor, and the colors are
+// looked up in the theme of the renderer they're rendered with, so switching
+// themes doesn't mean rebuilding styles:
+//
+//	r.SetTheme(lipgloss.Theme{
+//	    "primary": lipgloss.Color("#7D56F4"),
+//	    "error":   lipgloss.AdaptiveColor{Light: "#D70000", Dark: "#FF5F5F"},
+//	})
+//
+//	var errorStyle = r.NewStyle().Foreground(lipgloss.ThemeColor("error"))
+//
+// Colors in a theme can be any TerminalColor, including other theme colors.
+type Theme map[string]TerminalColor
+
+// ThemeColor is a color by name, looked up in the theme of the renderer it's
+// rendered with. Names that aren't in the theme render as NoColor.
+//
+//	errorColor := lipgloss.ThemeColor("error")
+type ThemeColor string
+
+func (c ThemeColor) color(r *Renderer) termenv.Color {
+	theme := r.Theme()
+
+	// Theme colors may refer to other theme colors, so follow them, but not
+	// around in circles.
+	var tc TerminalColor = c
+	for i := 0; i <= len(theme); i++ {
+		name, ok := tc.(ThemeColor)
+		if !ok {
+			return tc.color(r)
+		}
+		if tc, ok = theme[string(name)]; !ok || tc == nil {
+			break
+		}
+	}
+
+	return termenv.NoColor{}
+}
+
+// RGBA returns the RGBA value of this color. This satisfies the Go Color
+// interface. Note that on error we return black with 100% opacity, or:
+//
+// Red: 0x0, Green: 0x0, Blue: 0x0, Alpha: 0xFFFF.
+//
+// Deprecated.
+func (c ThemeColor) RGBA() (r, g, b, a uint32) {
+	return termenv.ConvertToRGB(c.color(renderer)).RGBA()
+}
+
+// Theme returns the renderer's theme.
+func (r *Renderer) Theme() Theme {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return r.theme
+}
+
+// SetTheme sets the theme used to look up theme colors rendered with the
+// renderer. The theme is copied, so changing it afterwards has no effect
+// until it's set again.
+//
+// This function is thread-safe.
+func (r *Renderer) SetTheme(t Theme) {
+	theme := make(Theme, len(t))
+	for name, c := range t {
+		theme[name] = c
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.theme = theme
+	r.trueColorRenderer = nil
+}
+
+// SetTheme sets the theme of the default renderer.
+//
+// This function is thread-safe.
+func SetTheme(t Theme) {
+	renderer.SetTheme(t)
+}
@@ -0,0 +1,71 @@
+package lipgloss
+
+import (
+	"io"
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestThemeColor(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.TrueColor)
+	r.SetHasDarkBackground(true)
+	r.SetTheme(Theme{
+		"primary": Color("#7d56f4"),
+		"error":   AdaptiveColor{Light: "#d70000", Dark: "#ff5f5f"},
+		"accent":  ThemeColor("primary"),
+		"loop":    ThemeColor("loop"),
+		"hover":   Lighten(ThemeColor("primary"), 0.1),
+	})
+
+	tt := []struct {
+		name     string
+		color    TerminalColor
+		expected termenv.Color
+	}{
+		{"plain", ThemeColor("primary"), termenv.RGBColor("#7d56f4")},
+		{"adaptive", ThemeColor("error"), termenv.RGBColor("#ff5f5f")},
+		{"alias", ThemeColor("accent"), termenv.RGBColor("#7d56f4")},
+		{"derived", ThemeColor("hover"), termenv.RGBColor("#9b70ff")},
+		{"missing", ThemeColor("nope"), termenv.NoColor{}},
+		{"circular", ThemeColor("loop"), termenv.NoColor{}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := tc.color.color(r)
+			if res != tc.expected {
+				t.Errorf("Expected %#v, got %#v", tc.expected, res)
+			}
+		})
+	}
+}
+
+func TestSetTheme(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.ANSI)
+
+	style := r.NewStyle().Foreground(ThemeColor("primary"))
+
+	tt := []struct {
+		name     string
+		theme    Theme
+		expected string
+	}{
+		{"no theme", nil, "hello"},
+		{"red", Theme{"primary": Color("1")}, "\x1b[31mhello\x1b[0m"},
+		{"blue", Theme{"primary": Color("4")}, "\x1b[34mhello\x1b[0m"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			r.SetTheme(tc.theme)
+			res := style.Render("hello")
+			if res != tc.expected {
+				t.Errorf("Expected:\n\n`%s`\n\nActual output:\n\n`%s`\n\n",
+					formatEscapes(tc.expected), formatEscapes(res))
+			}
+		})
+	}
+}
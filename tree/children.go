@@ -0,0 +1,138 @@
+package tree
+
+// Node defines a node in a tree.
+type Node interface {
+	// Value returns the value of the node.
+	Value() string
+
+	// Children returns the children of the node.
+	Children() Children
+
+	// Hidden returns whether the node is hidden.
+	Hidden() bool
+
+	// String returns the node, and any children, rendered as a string.
+	String() string
+}
+
+// Leaf is a node without children.
+type Leaf struct {
+	value  string
+	hidden bool
+}
+
+// NewLeaf returns a new Leaf with the given value.
+func NewLeaf(value string, hidden bool) *Leaf {
+	return &Leaf{value: value, hidden: hidden}
+}
+
+// Children of a Leaf node are always empty.
+func (l *Leaf) Children() Children {
+	return NodeChildren(nil)
+}
+
+// Value returns the value of the Leaf node.
+func (l *Leaf) Value() string {
+	return l.value
+}
+
+// Hidden returns whether the Leaf node is hidden.
+func (l *Leaf) Hidden() bool {
+	return l.hidden
+}
+
+// String returns the value of the Leaf node.
+func (l *Leaf) String() string {
+	return l.value
+}
+
+// Children is the interface that wraps the basic methods of a tree model.
+type Children interface {
+	// At returns the node at the given index.
+	At(index int) Node
+
+	// Length returns the number of nodes.
+	Length() int
+}
+
+// NodeChildren is a slice-based implementation of the Children interface.
+type NodeChildren []Node
+
+// Append appends a node to the children.
+func (n NodeChildren) Append(child Node) NodeChildren {
+	return append(n, child)
+}
+
+// Remove removes the node at the given index.
+func (n NodeChildren) Remove(index int) NodeChildren {
+	if index < 0 || index >= len(n) {
+		return n
+	}
+	return append(n[:index], n[index+1:]...)
+}
+
+// At returns the node at the given index, or nil if the index is out of
+// bounds.
+func (n NodeChildren) At(index int) Node {
+	if index < 0 || index >= len(n) {
+		return nil
+	}
+	return n[index]
+}
+
+// Length returns the number of nodes.
+func (n NodeChildren) Length() int {
+	return len(n)
+}
+
+// NewStringData returns a Children of leaves with the given values.
+func NewStringData(data ...string) Children {
+	result := make(NodeChildren, 0, len(data))
+	for _, d := range data {
+		result = append(result, NewLeaf(d, false))
+	}
+	return result
+}
+
+// Filter applies a filter on some children.
+type Filter struct {
+	data   Children
+	filter func(index int) bool
+}
+
+// NewFilter initializes a new Filter.
+func NewFilter(data Children) *Filter {
+	return &Filter{data: data}
+}
+
+// Filter applies the given filter function to the children.
+func (m *Filter) Filter(f func(index int) bool) *Filter {
+	m.filter = f
+	return m
+}
+
+// At returns the node at the given index.
+func (m *Filter) At(index int) Node {
+	j := 0
+	for i := 0; i < m.data.Length(); i++ {
+		if m.filter == nil || m.filter(i) {
+			if j == index {
+				return m.data.At(i)
+			}
+			j++
+		}
+	}
+
+	return nil
+}
+
+// Length returns the number of nodes matching the filter.
+func (m *Filter) Length() int {
+	j := 0
+	for i := 0; i < m.data.Length(); i++ {
+		if m.filter == nil || m.filter(i) {
+			j++
+		}
+	}
+	return j
+}
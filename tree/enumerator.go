@@ -0,0 +1,75 @@
+package tree
+
+// Enumerator enumerates a tree. Typically, this is used to draw the branches
+// for the tree nodes and is likely to change for the last child.
+type Enumerator func(children Children, index int) string
+
+// DefaultEnumerator enumerates a tree.
+//
+//	├── Foo
+//	├── Bar
+//	├── Baz
+//	└── Qux
+func DefaultEnumerator(children Children, index int) string {
+	if lastVisible(children) == index {
+		return "└──"
+	}
+	return "├──"
+}
+
+// RoundedEnumerator enumerates a tree with rounded edges.
+//
+//	├── Foo
+//	├── Bar
+//	├── Baz
+//	╰── Qux
+func RoundedEnumerator(children Children, index int) string {
+	if lastVisible(children) == index {
+		return "╰──"
+	}
+	return "├──"
+}
+
+// Indenter indents the children of a tree, as well as any additional lines
+// of a multi-line node. Indenters allow for displaying nested tree items
+// with connecting borders to sibling nodes. Indents are rendered with the
+// enumerator style, so they should be as wide as the enumerator itself.
+//
+// For example, ignoring hidden children, the default indenter would be:
+//
+//	func DefaultIndenter(children Children, index int) string {
+//		if children.Length()-1 == index {
+//			return "   "
+//		}
+//		return "│  "
+//	}
+type Indenter func(children Children, index int) string
+
+// DefaultIndenter indents a tree for nested trees and multi-line content.
+//
+//	├── Foo
+//	├── Bar
+//	│   ├── Qux
+//	│   ├── Quux
+//	│   │   ├── Foo
+//	│   │   └── Bar
+//	│   └── Quuux
+//	└── Baz
+func DefaultIndenter(children Children, index int) string {
+	if lastVisible(children) == index {
+		return "   "
+	}
+	return "│  "
+}
+
+// lastVisible returns the index of the last of the children that isn't
+// hidden, which is the one that closes off the branch, or -1 if they're all
+// hidden.
+func lastVisible(children Children) int {
+	for i := children.Length() - 1; i >= 0; i-- {
+		if child := children.At(i); child != nil && !child.Hidden() {
+			return i
+		}
+	}
+	return -1
+}
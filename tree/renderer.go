@@ -0,0 +1,134 @@
+package tree
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// StyleFunc allows the tree to be styled per item.
+//
+// It takes the children of the current level and the index of the item
+// being rendered, and returns the lipgloss Style to use for it.
+//
+// Example:
+//
+//	t := tree.Root("Food").
+//	    Child("Apples", "Bananas", "Cherries").
+//	    ItemStyleFunc(func(children tree.Children, i int) lipgloss.Style {
+//	        if i%2 == 0 {
+//	            return EvenStyle
+//	        }
+//	        return OddStyle
+//	    })
+type StyleFunc func(children Children, i int) lipgloss.Style
+
+// style is the styling applied to the tree.
+type style struct {
+	enumeratorFunc StyleFunc
+	itemFunc       StyleFunc
+	root           lipgloss.Style
+}
+
+// renderer is responsible for rendering trees.
+type renderer struct {
+	style      style
+	enumerator Enumerator
+	indenter   Indenter
+}
+
+// newRenderer returns a renderer with the default style, enumerator and
+// indenter.
+func newRenderer() *renderer {
+	return &renderer{
+		style: style{
+			enumeratorFunc: func(Children, int) lipgloss.Style {
+				return lipgloss.NewStyle().PaddingRight(1)
+			},
+			itemFunc: func(Children, int) lipgloss.Style {
+				return lipgloss.NewStyle()
+			},
+		},
+		enumerator: DefaultEnumerator,
+		indenter:   DefaultIndenter,
+	}
+}
+
+// render renders a node and its children. Each line of the output is
+// prefixed with the given prefix, which carries the indentation of the
+// parent levels.
+func (r *renderer) render(node Node, root bool, prefix string) string {
+	if node.Hidden() {
+		return ""
+	}
+
+	var (
+		strs     []string
+		maxWidth int
+		children = node.Children()
+	)
+
+	// Print the root node's value if it's not empty.
+	if value := node.Value(); value != "" && root {
+		strs = append(strs, r.style.root.Render(value))
+	}
+
+	// Enumerators may differ in width (think "9." and "10."), so find the
+	// widest one in order to align them.
+	for i := 0; i < children.Length(); i++ {
+		enum := r.style.enumeratorFunc(children, i).Render(r.enumerator(children, i))
+		maxWidth = max(lipgloss.Width(enum), maxWidth)
+	}
+
+	for i := 0; i < children.Length(); i++ {
+		child := children.At(i)
+		if child == nil || child.Hidden() {
+			continue
+		}
+
+		enumStyle := r.style.enumeratorFunc(children, i)
+		itemStyle := r.style.itemFunc(children, i)
+		indent := enumStyle.Render(r.indenter(children, i))
+
+		enum := enumStyle.Render(r.enumerator(children, i))
+		if w := maxWidth - lipgloss.Width(enum); w > 0 {
+			enum = strings.Repeat(" ", w) + enum
+		}
+
+		item := itemStyle.Render(child.Value())
+
+		// Multi-line items hang under the enumerator, so continue the
+		// enumerator column with the indenter, and the parent prefix along
+		// with it.
+		for lipgloss.Height(item) > lipgloss.Height(enum) {
+			enum = lipgloss.JoinVertical(lipgloss.Left, enum, indent)
+		}
+		linePrefix := prefix
+		for lipgloss.Height(enum) > lipgloss.Height(linePrefix) {
+			linePrefix = lipgloss.JoinVertical(lipgloss.Left, linePrefix, prefix)
+		}
+
+		strs = append(strs, lipgloss.JoinHorizontal(lipgloss.Top, linePrefix, enum, item))
+
+		if child.Children().Length() > 0 {
+			// Subtrees can bring their own renderer, otherwise they use ours.
+			cr := r
+			if t, ok := child.(*Tree); ok && t.r != nil {
+				cr = t.r
+			}
+			if s := cr.render(child, false, prefix+indent); s != "" {
+				strs = append(strs, s)
+			}
+		}
+	}
+
+	return strings.Join(strs, "\n")
+}
+
+// max returns the greater of two integers.
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,270 @@
+// Package tree allows you to build trees, as simple or complicated as you
+// need.
+//
+// Define a tree with a root and children, optionally nesting subtrees:
+//
+//	t := tree.Root(".").
+//	    Child("macOS").
+//	    Child(
+//	        tree.Root("Linux").
+//	            Child("NixOS").
+//	            Child("Arch Linux (btw)").
+//	            Child("Void Linux"),
+//	    ).
+//	    Child("BSD")
+//
+//	fmt.Println(t)
+//
+// Which renders:
+//
+//	.
+//	├── macOS
+//	├── Linux
+//	│   ├── NixOS
+//	│   ├── Arch Linux (btw)
+//	│   └── Void Linux
+//	└── BSD
+package tree
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Tree implements a Node. It has a root value and a list of children, which
+// may themselves be trees.
+type Tree struct {
+	value    string
+	hidden   bool
+	children NodeChildren
+
+	r *renderer
+}
+
+// New returns a new, empty tree. Use Root to set a root value and Child to
+// add children.
+func New() *Tree {
+	return &Tree{}
+}
+
+// Root returns a new tree with the given root value.
+//
+//	t := tree.Root("Root")
+func Root(root interface{}) *Tree {
+	return New().Root(root)
+}
+
+// Root sets the root value of this tree.
+func (t *Tree) Root(root interface{}) *Tree {
+	switch r := root.(type) {
+	case string:
+		t.value = r
+	case Node:
+		t.value = r.Value()
+	case fmt.Stringer:
+		t.value = r.String()
+	default:
+		t.value = fmt.Sprintf("%v", r)
+	}
+	return t
+}
+
+// Child adds children to the tree. Children can be strings, Nodes (such as
+// other trees), Children, or slices of strings and Nodes. Anything else is
+// formatted with fmt.Sprintf.
+//
+// A tree without a root value is treated as a sublist of the child before it,
+// which is handy for nesting without repeating the parent's value:
+//
+//	t := tree.New().
+//	    Child(
+//	        "Foo",
+//	        tree.New().Child("Bar", "Baz"),
+//	        "Qux",
+//	    )
+//
+// Renders:
+//
+//	├── Foo
+//	│   ├── Bar
+//	│   └── Baz
+//	└── Qux
+func (t *Tree) Child(children ...interface{}) *Tree {
+	for _, child := range children {
+		switch item := child.(type) {
+		case *Tree:
+			if item.value == "" && len(t.children) > 0 {
+				// Nest the rootless tree under the previous child.
+				prev := t.children[len(t.children)-1]
+				item.value = prev.Value()
+				item.hidden = item.hidden || prev.Hidden()
+				if pt, ok := prev.(*Tree); ok {
+					// Copy the previous child's children rather than
+					// appending to them, as they may share spare capacity.
+					n := len(pt.children)
+					item.children = append(pt.children[:n:n], item.children...)
+					if item.r == nil {
+						item.r = pt.r
+					}
+				}
+				t.children[len(t.children)-1] = item
+				continue
+			}
+			t.children = t.children.Append(item)
+		case Node:
+			t.children = t.children.Append(item)
+		case Children:
+			for i := 0; i < item.Length(); i++ {
+				t.Child(item.At(i))
+			}
+		case []interface{}:
+			t.Child(item...)
+		case []string:
+			for _, s := range item {
+				t.children = t.children.Append(NewLeaf(s, false))
+			}
+		case nil:
+			continue
+		case string:
+			t.children = t.children.Append(NewLeaf(item, false))
+		case fmt.Stringer:
+			t.children = t.children.Append(NewLeaf(item.String(), false))
+		default:
+			t.children = t.children.Append(NewLeaf(fmt.Sprintf("%v", item), false))
+		}
+	}
+	return t
+}
+
+// Hide sets whether to hide the tree, along with all of its children.
+func (t *Tree) Hide(hide bool) *Tree {
+	t.hidden = hide
+	return t
+}
+
+// Hidden returns whether the tree is hidden.
+func (t *Tree) Hidden() bool {
+	return t.hidden
+}
+
+// Value returns the root value of the tree.
+func (t *Tree) Value() string {
+	return t.value
+}
+
+// Children returns the children of the tree.
+func (t *Tree) Children() Children {
+	return t.children
+}
+
+// String returns the tree rendered as a string.
+func (t *Tree) String() string {
+	r := t.r
+	if r == nil {
+		r = newRenderer()
+	}
+	return r.render(t, true, "")
+}
+
+// Render returns the tree rendered as a string.
+func (t *Tree) Render() string {
+	return t.String()
+}
+
+// EnumeratorStyle sets the style for all enumerators of this tree level.
+//
+// To set the style per item, use EnumeratorStyleFunc.
+func (t *Tree) EnumeratorStyle(style lipgloss.Style) *Tree {
+	t.ensureRenderer().style.enumeratorFunc = func(Children, int) lipgloss.Style {
+		return style
+	}
+	return t
+}
+
+// EnumeratorStyleFunc sets the enumeration style function. Use this function
+// for conditional styling.
+//
+//	t := tree.Root("Root").
+//	    Child("Foo", "Bar").
+//	    EnumeratorStyleFunc(func(_ tree.Children, i int) lipgloss.Style {
+//	        if i == selected {
+//	            return selectedEnumStyle
+//	        }
+//	        return enumStyle
+//	    })
+func (t *Tree) EnumeratorStyleFunc(fn StyleFunc) *Tree {
+	if fn == nil {
+		fn = func(Children, int) lipgloss.Style { return lipgloss.NewStyle() }
+	}
+	t.ensureRenderer().style.enumeratorFunc = fn
+	return t
+}
+
+// ItemStyle sets the style for all items of this tree level.
+//
+// To set the style per item, use ItemStyleFunc.
+func (t *Tree) ItemStyle(style lipgloss.Style) *Tree {
+	t.ensureRenderer().style.itemFunc = func(Children, int) lipgloss.Style {
+		return style
+	}
+	return t
+}
+
+// ItemStyleFunc sets the item style function. Use this for conditional
+// styling, for example:
+//
+//	t := tree.Root("Root").
+//	    Child("Foo", "Bar").
+//	    ItemStyleFunc(func(_ tree.Children, i int) lipgloss.Style {
+//	        if i == selected {
+//	            return selectedStyle
+//	        }
+//	        return itemStyle
+//	    })
+func (t *Tree) ItemStyleFunc(fn StyleFunc) *Tree {
+	if fn == nil {
+		fn = func(Children, int) lipgloss.Style { return lipgloss.NewStyle() }
+	}
+	t.ensureRenderer().style.itemFunc = fn
+	return t
+}
+
+// RootStyle sets the style of the root value.
+func (t *Tree) RootStyle(style lipgloss.Style) *Tree {
+	t.ensureRenderer().style.root = style
+	return t
+}
+
+// Enumerator sets the enumerator implementation. This can be used to change
+// the way the branches indicators look. Lipgloss includes predefined
+// enumerators for classic and rounded trees. For example, you can have a
+// rounded tree:
+//
+//	tree.New().
+//	    Child("Foo", "Bar").
+//	    Enumerator(tree.RoundedEnumerator)
+func (t *Tree) Enumerator(enum Enumerator) *Tree {
+	t.ensureRenderer().enumerator = enum
+	return t
+}
+
+// Indenter sets the indenter implementation. This is used to indent the
+// children of this tree and any additional lines of multi-line items. Each
+// subtree can set its own indenter, allowing for per-level indentation.
+//
+//	tree.New().
+//	    Child("Foo", tree.New().Child("Bar")).
+//	    Indenter(func(tree.Children, int) string { return "  " })
+func (t *Tree) Indenter(indenter Indenter) *Tree {
+	t.ensureRenderer().indenter = indenter
+	return t
+}
+
+// ensureRenderer returns the tree's renderer, creating one if needed.
+func (t *Tree) ensureRenderer() *renderer {
+	if t.r == nil {
+		t.r = newRenderer()
+	}
+	return t.r
+}
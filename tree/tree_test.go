@@ -0,0 +1,210 @@
+package tree
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestTree(t *testing.T) {
+	tree := Root(".").
+		Child("macOS").
+		Child(
+			Root("Linux").
+				Child("NixOS").
+				Child("Arch Linux (btw)").
+				Child("Void Linux"),
+		).
+		Child(
+			Root("BSD").
+				Child("FreeBSD").
+				Child("OpenBSD"),
+		)
+
+	expected := strings.TrimSpace(`
+.
+├── macOS
+├── Linux
+│   ├── NixOS
+│   ├── Arch Linux (btw)
+│   └── Void Linux
+└── BSD
+    ├── FreeBSD
+    └── OpenBSD
+`)
+
+	if tree.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, tree.String())
+	}
+}
+
+func TestTreeRootless(t *testing.T) {
+	tree := New().
+		Child(
+			"Foo",
+			New().Child("Bar", "Baz"),
+			"Qux",
+		)
+
+	expected := strings.TrimSpace(`
+├── Foo
+│   ├── Bar
+│   └── Baz
+└── Qux
+`)
+
+	if tree.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, tree.String())
+	}
+}
+
+func TestTreeCustomEnumerator(t *testing.T) {
+	tree := Root("Root").
+		Child(
+			"Foo",
+			Root("Bar").
+				Child("Baz").
+				Enumerator(func(Children, int) string { return "->" }).
+				Indenter(func(Children, int) string { return "  " }).
+				EnumeratorStyle(lipgloss.NewStyle().PaddingRight(1)),
+			"Qux",
+		).
+		Enumerator(RoundedEnumerator)
+
+	expected := strings.TrimSpace(`
+Root
+├── Foo
+├── Bar
+│   -> Baz
+╰── Qux
+`)
+
+	if tree.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, tree.String())
+	}
+}
+
+func TestTreeMultiline(t *testing.T) {
+	tree := Root("Root").
+		Child(
+			"Foo\nBar",
+			Root("Baz\nQux").
+				Child("Quux\nQuuux"),
+		)
+
+	expected := strings.Join([]string{
+		"Root",
+		"├── Foo",
+		"│   Bar",
+		"└── Baz",
+		"    Qux",
+		"    └── Quux ",
+		"        Quuux",
+	}, "\n")
+
+	if tree.String() != expected {
+		t.Fatalf("expected:\n\n%q\n\ngot:\n\n%q", expected, tree.String())
+	}
+}
+
+func TestTreeHidden(t *testing.T) {
+	tree := Root("Root").
+		Child(
+			"Foo",
+			Root("Bar").Child("Baz").Hide(true),
+			"Qux",
+		)
+
+	expected := strings.TrimSpace(`
+Root
+├── Foo
+└── Qux
+`)
+
+	if tree.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, tree.String())
+	}
+}
+
+func TestTreeHiddenLast(t *testing.T) {
+	tree := Root("Root").
+		Child(
+			"Foo",
+			Root("Bar").Child("Baz", NewLeaf("Qux", false), NewLeaf("Quux", true)),
+			NewLeaf("Quuux", true),
+		)
+
+	expected := strings.TrimSpace(`
+Root
+├── Foo
+└── Bar
+    ├── Baz
+    └── Qux
+`)
+
+	if tree.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, tree.String())
+	}
+}
+
+func TestTreeNestShared(t *testing.T) {
+	// Foo has room for another child, which mustn't be shared with the tree
+	// nested under it.
+	foo := Root("Foo").Child("Bar", "Baz", "Qux")
+	tree := Root("Root").Child(foo, New().Child("Quux"))
+	foo.Child("Quuux")
+
+	expected := strings.TrimSpace(`
+Root
+└── Foo
+    ├── Bar
+    ├── Baz
+    ├── Qux
+    └── Quux
+`)
+
+	if tree.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, tree.String())
+	}
+}
+
+func TestTreeStyleFunc(t *testing.T) {
+	tree := Root("Root").
+		Child("Foo", "Bar").
+		ItemStyleFunc(func(_ Children, i int) lipgloss.Style {
+			return lipgloss.NewStyle().SetString(strings.Repeat("*", i+1))
+		})
+
+	expected := strings.TrimSpace(`
+Root
+├── * Foo
+└── ** Bar
+`)
+
+	if tree.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, tree.String())
+	}
+}
+
+func TestFilter(t *testing.T) {
+	data := NewFilter(NewStringData("Foo", "Bar", "Baz", "Qux")).
+		Filter(func(index int) bool {
+			return index%2 == 0
+		})
+
+	tree := Root("Root").Child(data)
+
+	expected := strings.TrimSpace(`
+Root
+├── Foo
+└── Baz
+`)
+
+	if data.Length() != 2 {
+		t.Fatalf("expected 2 children, got %d", data.Length())
+	}
+	if tree.String() != expected {
+		t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, tree.String())
+	}
+}
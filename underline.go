@@ -0,0 +1,109 @@
+package lipgloss
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/muesli/termenv"
+)
+
+// UnderlineStyle is the shape of an underline. Terminals that don't support
+// extended underlines will generally fall back to a single underline.
+type UnderlineStyle int
+
+// Underline styles.
+const (
+	UnderlineNone UnderlineStyle = iota
+	UnderlineSingle
+	UnderlineDouble
+	UnderlineCurly
+	UnderlineDotted
+	UnderlineDashed
+)
+
+// underlineStyleNames are the names of the underline styles, as used in
+// style sheets.
+var underlineStyleNames = [...]string{
+	UnderlineNone:   "none",
+	UnderlineSingle: "single",
+	UnderlineDouble: "double",
+	UnderlineCurly:  "curly",
+	UnderlineDotted: "dotted",
+	UnderlineDashed: "dashed",
+}
+
+// String returns the name of the underline style.
+func (u UnderlineStyle) String() string {
+	if u < 0 || int(u) >= len(underlineStyleNames) {
+		return "UnderlineStyle(" + strconv.Itoa(int(u)) + ")"
+	}
+	return underlineStyleNames[u]
+}
+
+// MarshalText encodes the underline style by name.
+func (u UnderlineStyle) MarshalText() ([]byte, error) {
+	if u < 0 || int(u) >= len(underlineStyleNames) {
+		return nil, fmt.Errorf("invalid underline style %d", int(u))
+	}
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText decodes an underline style from its name.
+func (u *UnderlineStyle) UnmarshalText(text []byte) error {
+	v, err := parseSheetValueText(string(text))
+	if err != nil {
+		return err
+	}
+	*u, err = parseSheetUnderlineStyle(v)
+	return err
+}
+
+// sequence returns the SGR parameters that enable the underline style.
+func (u UnderlineStyle) sequence() string {
+	switch u {
+	case UnderlineSingle:
+		return "4"
+	case UnderlineDouble:
+		return "4:2"
+	case UnderlineCurly:
+		return "4:3"
+	case UnderlineDotted:
+		return "4:4"
+	case UnderlineDashed:
+		return "4:5"
+	default:
+		return ""
+	}
+}
+
+// underlineColorSequence returns the SGR parameters that set the underline
+// color. The color has already been degraded to the renderer's color profile,
+// so we only need to pick the matching form of SGR 58.
+func underlineColorSequence(c termenv.Color) string {
+	switch c := c.(type) {
+	case termenv.ANSIColor:
+		return "58;5;" + strconv.Itoa(int(c))
+	case termenv.ANSI256Color:
+		return "58;5;" + strconv.Itoa(int(c))
+	case termenv.RGBColor:
+		r, g, b := termenv.ConvertToRGB(c).RGB255()
+		return fmt.Sprintf("58;2;%d;%d;%d", r, g, b)
+	default:
+		return ""
+	}
+}
+
+// styled is like termenv.Style.Styled, but first emits the given SGR
+// parameters, which is how we render the things termenv doesn't know about,
+// like extended underlines.
+func styled(te termenv.Style, seq, str string) string {
+	if seq == "" || str == "" {
+		return te.Styled(str)
+	}
+	out := te.Styled(str)
+	if out == str {
+		// termenv had nothing to apply, so it won't reset for us either.
+		out += sgrReset
+	}
+	return "\x1b[" + seq + "m" + out
+}
@@ -2,158 +2,176 @@ package lipgloss
 
 // UnsetBold removes the bold style rule, if set.
 func (s Style) UnsetBold() Style {
-	delete(s.rules, boldKey)
+	s.unset(boldKey)
 	return s
 }
 
 // UnsetItalic removes the italic style rule, if set.
 func (s Style) UnsetItalic() Style {
-	delete(s.rules, italicKey)
+	s.unset(italicKey)
 	return s
 }
 
 // UnsetUnderline removes the underline style rule, if set.
 func (s Style) UnsetUnderline() Style {
-	delete(s.rules, underlineKey)
+	s.unset(underlineKey)
+	return s
+}
+
+// UnsetUnderlineStyle removes the underline style rule, if set. Underlining
+// itself is controlled by Underline and is left as is.
+func (s Style) UnsetUnderlineStyle() Style {
+	s.unset(underlineStyleKey)
+	return s
+}
+
+// UnsetUnderlineColor removes the underline color rule, if set.
+func (s Style) UnsetUnderlineColor() Style {
+	s.unset(underlineColorKey)
 	return s
 }
 
 // UnsetStrikethrough removes the strikethrough style rule, if set.
 func (s Style) UnsetStrikethrough() Style {
-	delete(s.rules, strikethroughKey)
+	s.unset(strikethroughKey)
 	return s
 }
 
 // UnsetReverse removes the reverse style rule, if set.
 func (s Style) UnsetReverse() Style {
-	delete(s.rules, reverseKey)
+	s.unset(reverseKey)
 	return s
 }
 
 // UnsetBlink removes the blink style rule, if set.
 func (s Style) UnsetBlink() Style {
-	delete(s.rules, blinkKey)
+	s.unset(blinkKey)
 	return s
 }
 
 // UnsetFaint removes the faint style rule, if set.
 func (s Style) UnsetFaint() Style {
-	delete(s.rules, faintKey)
+	s.unset(faintKey)
 	return s
 }
 
 // UnsetForeground removes the foreground style rule, if set.
 func (s Style) UnsetForeground() Style {
-	delete(s.rules, foregroundKey)
+	s.unset(foregroundKey)
 	return s
 }
 
 // UnsetBackground removes the background style rule, if set.
 func (s Style) UnsetBackground() Style {
-	delete(s.rules, backgroundKey)
+	s.unset(backgroundKey)
 	return s
 }
 
 // UnsetWidth removes the width style rule, if set.
 func (s Style) UnsetWidth() Style {
-	delete(s.rules, widthKey)
+	s.unset(widthKey)
 	return s
 }
 
 // UnsetHeight removes the height style rule, if set.
 func (s Style) UnsetHeight() Style {
-	delete(s.rules, heightKey)
+	s.unset(heightKey)
 	return s
 }
 
 // UnsetAlign removes the horizontal and vertical text alignment style rule, if set.
 func (s Style) UnsetAlign() Style {
-	delete(s.rules, alignHorizontalKey)
-	delete(s.rules, alignVerticalKey)
+	s.unset(alignHorizontalKey, alignVerticalKey)
 	return s
 }
 
 // UnsetAlignHorizontal removes the horizontal text alignment style rule, if set.
 func (s Style) UnsetAlignHorizontal() Style {
-	delete(s.rules, alignHorizontalKey)
+	s.unset(alignHorizontalKey)
 	return s
 }
 
 // UnsetAlignVertical removes the vertical text alignment style rule, if set.
 func (s Style) UnsetAlignVertical() Style {
-	delete(s.rules, alignVerticalKey)
+	s.unset(alignVerticalKey)
+	return s
+}
+
+// UnsetJustify removes the justify style rule, if set.
+func (s Style) UnsetJustify() Style {
+	s.unset(justifyKey)
+	return s
+}
+
+// UnsetHyphenate removes the hyphenate style rule, if set.
+func (s Style) UnsetHyphenate() Style {
+	s.unset(hyphenateKey)
 	return s
 }
 
 // UnsetPadding removes all padding style rules.
 func (s Style) UnsetPadding() Style {
-	delete(s.rules, paddingLeftKey)
-	delete(s.rules, paddingRightKey)
-	delete(s.rules, paddingTopKey)
-	delete(s.rules, paddingBottomKey)
+	s.unset(paddingLeftKey, paddingRightKey, paddingTopKey, paddingBottomKey)
 	return s
 }
 
 // UnsetPaddingLeft removes the left padding style rule, if set.
 func (s Style) UnsetPaddingLeft() Style {
-	delete(s.rules, paddingLeftKey)
+	s.unset(paddingLeftKey)
 	return s
 }
 
 // UnsetPaddingRight removes the right padding style rule, if set.
 func (s Style) UnsetPaddingRight() Style {
-	delete(s.rules, paddingRightKey)
+	s.unset(paddingRightKey)
 	return s
 }
 
 // UnsetPaddingTop removes the top padding style rule, if set.
 func (s Style) UnsetPaddingTop() Style {
-	delete(s.rules, paddingTopKey)
+	s.unset(paddingTopKey)
 	return s
 }
 
 // UnsetPaddingBottom removes the bottom padding style rule, if set.
 func (s Style) UnsetPaddingBottom() Style {
-	delete(s.rules, paddingBottomKey)
+	s.unset(paddingBottomKey)
 	return s
 }
 
 // UnsetColorWhitespace removes the rule for coloring padding, if set.
 func (s Style) UnsetColorWhitespace() Style {
-	delete(s.rules, colorWhitespaceKey)
+	s.unset(colorWhitespaceKey)
 	return s
 }
 
 // UnsetMargins removes all margin style rules.
 func (s Style) UnsetMargins() Style {
-	delete(s.rules, marginLeftKey)
-	delete(s.rules, marginRightKey)
-	delete(s.rules, marginTopKey)
-	delete(s.rules, marginBottomKey)
+	s.unset(marginLeftKey, marginRightKey, marginTopKey, marginBottomKey)
 	return s
 }
 
 // UnsetMarginLeft removes the left margin style rule, if set.
 func (s Style) UnsetMarginLeft() Style {
-	delete(s.rules, marginLeftKey)
+	s.unset(marginLeftKey)
 	return s
 }
 
 // UnsetMarginRight removes the right margin style rule, if set.
 func (s Style) UnsetMarginRight() Style {
-	delete(s.rules, marginRightKey)
+	s.unset(marginRightKey)
 	return s
 }
 
 // UnsetMarginTop removes the top margin style rule, if set.
 func (s Style) UnsetMarginTop() Style {
-	delete(s.rules, marginTopKey)
+	s.unset(marginTopKey)
 	return s
 }
 
 // UnsetMarginBottom removes the bottom margin style rule, if set.
 func (s Style) UnsetMarginBottom() Style {
-	delete(s.rules, marginBottomKey)
+	s.unset(marginBottomKey)
 	return s
 }
 
@@ -161,153 +179,208 @@ func (s Style) UnsetMarginBottom() Style {
 // margin's background color can be set from the background color of another
 // style during inheritance.
 func (s Style) UnsetMarginBackground() Style {
-	delete(s.rules, marginBackgroundKey)
+	s.unset(marginBackgroundKey)
 	return s
 }
 
 // UnsetBorderStyle removes the border style rule, if set.
 func (s Style) UnsetBorderStyle() Style {
-	delete(s.rules, borderStyleKey)
+	s.unset(borderStyleKey)
 	return s
 }
 
 // UnsetBorderTop removes the border top style rule, if set.
 func (s Style) UnsetBorderTop() Style {
-	delete(s.rules, borderTopKey)
+	s.unset(borderTopKey)
 	return s
 }
 
 // UnsetBorderRight removes the border right style rule, if set.
 func (s Style) UnsetBorderRight() Style {
-	delete(s.rules, borderRightKey)
+	s.unset(borderRightKey)
 	return s
 }
 
 // UnsetBorderBottom removes the border bottom style rule, if set.
 func (s Style) UnsetBorderBottom() Style {
-	delete(s.rules, borderBottomKey)
+	s.unset(borderBottomKey)
 	return s
 }
 
 // UnsetBorderLeft removes the border left style rule, if set.
 func (s Style) UnsetBorderLeft() Style {
-	delete(s.rules, borderLeftKey)
+	s.unset(borderLeftKey)
 	return s
 }
 
 // UnsetBorderForeground removes all border foreground color styles, if set.
 func (s Style) UnsetBorderForeground() Style {
-	delete(s.rules, borderTopForegroundKey)
-	delete(s.rules, borderRightForegroundKey)
-	delete(s.rules, borderBottomForegroundKey)
-	delete(s.rules, borderLeftForegroundKey)
+	s.unset(borderTopForegroundKey, borderRightForegroundKey, borderBottomForegroundKey, borderLeftForegroundKey)
 	return s
 }
 
 // UnsetBorderTopForeground removes the top border foreground color rule,
 // if set.
 func (s Style) UnsetBorderTopForeground() Style {
-	delete(s.rules, borderTopForegroundKey)
+	s.unset(borderTopForegroundKey)
 	return s
 }
 
 // UnsetBorderRightForeground removes the right border foreground color rule,
 // if set.
 func (s Style) UnsetBorderRightForeground() Style {
-	delete(s.rules, borderRightForegroundKey)
+	s.unset(borderRightForegroundKey)
 	return s
 }
 
 // UnsetBorderBottomForeground removes the bottom border foreground color
 // rule, if set.
 func (s Style) UnsetBorderBottomForeground() Style {
-	delete(s.rules, borderBottomForegroundKey)
+	s.unset(borderBottomForegroundKey)
 	return s
 }
 
 // UnsetBorderLeftForeground removes the left border foreground color rule,
 // if set.
 func (s Style) UnsetBorderLeftForeground() Style {
-	delete(s.rules, borderLeftForegroundKey)
+	s.unset(borderLeftForegroundKey)
 	return s
 }
 
 // UnsetBorderBackground removes all border background color styles, if
 // set.
 func (s Style) UnsetBorderBackground() Style {
-	delete(s.rules, borderTopBackgroundKey)
-	delete(s.rules, borderRightBackgroundKey)
-	delete(s.rules, borderBottomBackgroundKey)
-	delete(s.rules, borderLeftBackgroundKey)
+	s.unset(borderTopBackgroundKey, borderRightBackgroundKey, borderBottomBackgroundKey, borderLeftBackgroundKey)
 	return s
 }
 
 // UnsetBorderTopBackgroundColor removes the top border background color rule,
 // if set.
 func (s Style) UnsetBorderTopBackgroundColor() Style {
-	delete(s.rules, borderTopBackgroundKey)
+	s.unset(borderTopBackgroundKey)
 	return s
 }
 
 // UnsetBorderRightBackground removes the right border background color
 // rule, if set.
 func (s Style) UnsetBorderRightBackground() Style {
-	delete(s.rules, borderRightBackgroundKey)
+	s.unset(borderRightBackgroundKey)
 	return s
 }
 
 // UnsetBorderBottomBackground removes the bottom border background color
 // rule, if set.
 func (s Style) UnsetBorderBottomBackground() Style {
-	delete(s.rules, borderBottomBackgroundKey)
+	s.unset(borderBottomBackgroundKey)
 	return s
 }
 
 // UnsetBorderLeftBackground removes the left border color rule, if set.
 func (s Style) UnsetBorderLeftBackground() Style {
-	delete(s.rules, borderLeftBackgroundKey)
+	s.unset(borderLeftBackgroundKey)
+	return s
+}
+
+// UnsetBorderTitle removes the border title rule, if set.
+func (s Style) UnsetBorderTitle() Style {
+	s.unset(borderTitleKey)
+	return s
+}
+
+// UnsetBorderTitleAlign removes the border title alignment rule, if set.
+func (s Style) UnsetBorderTitleAlign() Style {
+	s.unset(borderTitleAlignKey)
+	return s
+}
+
+// UnsetBorderTitleStyle removes the border title style rule, if set.
+func (s Style) UnsetBorderTitleStyle() Style {
+	s.unset(borderTitleStyleKey)
+	return s
+}
+
+// UnsetBorderFooter removes the border footer rule, if set.
+func (s Style) UnsetBorderFooter() Style {
+	s.unset(borderFooterKey)
+	return s
+}
+
+// UnsetBorderFooterAlign removes the border footer alignment rule, if set.
+func (s Style) UnsetBorderFooterAlign() Style {
+	s.unset(borderFooterAlignKey)
+	return s
+}
+
+// UnsetBorderFooterStyle removes the border footer style rule, if set.
+func (s Style) UnsetBorderFooterStyle() Style {
+	s.unset(borderFooterStyleKey)
 	return s
 }
 
 // UnsetInline removes the inline style rule, if set.
 func (s Style) UnsetInline() Style {
-	delete(s.rules, inlineKey)
+	s.unset(inlineKey)
 	return s
 }
 
 // UnsetMaxWidth removes the max width style rule, if set.
 func (s Style) UnsetMaxWidth() Style {
-	delete(s.rules, maxWidthKey)
+	s.unset(maxWidthKey)
 	return s
 }
 
 // UnsetMaxHeight removes the max height style rule, if set.
 func (s Style) UnsetMaxHeight() Style {
-	delete(s.rules, maxHeightKey)
+	s.unset(maxHeightKey)
+	return s
+}
+
+// UnsetEllipsis removes the ellipsis style rule, if set.
+func (s Style) UnsetEllipsis() Style {
+	s.unset(ellipsisKey)
+	return s
+}
+
+// UnsetEllipsisPosition removes the ellipsis position style rule, if set.
+func (s Style) UnsetEllipsisPosition() Style {
+	s.unset(ellipsisPositionKey)
+	return s
+}
+
+// UnsetMaxHeightIndicator removes the max height indicator style rule, if
+// set.
+func (s Style) UnsetMaxHeightIndicator() Style {
+	s.unset(maxHeightIndicatorKey)
 	return s
 }
 
 // UnsetTabWidth removes the tab width style rule, if set.
 func (s Style) UnsetTabWidth() Style {
-	delete(s.rules, tabWidthKey)
+	s.unset(tabWidthKey)
 	return s
 }
 
 // UnsetUnderlineSpaces removes the value set by UnderlineSpaces.
 func (s Style) UnsetUnderlineSpaces() Style {
-	delete(s.rules, underlineSpacesKey)
+	s.unset(underlineSpacesKey)
 	return s
 }
 
 // UnsetStrikethroughSpaces removes the value set by StrikethroughSpaces.
 func (s Style) UnsetStrikethroughSpaces() Style {
-	delete(s.rules, strikethroughSpacesKey)
+	s.unset(strikethroughSpacesKey)
 	return s
 }
 
 // UnsetTransform removes the value set by Transform.
 func (s Style) UnsetTransform() Style {
-	delete(s.rules, transformKey)
+	s.unset(transformKey)
+	return s
+}
+
+// UnsetHyperlink removes the value set by Hyperlink.
+func (s Style) UnsetHyperlink() Style {
+	s.unset(hyperlinkKey)
 	return s
 }
 
@@ -3,7 +3,6 @@ package lipgloss
 import (
 	"strings"
 
-	"github.com/muesli/reflow/ansi"
 	"github.com/muesli/termenv"
 )
 
@@ -34,23 +33,23 @@ func (w whitespace) render(width int) string {
 		w.chars = " "
 	}
 
-	r := []rune(w.chars)
+	chars := graphemes(w.chars)
 	j := 0
 	b := strings.Builder{}
 
-	// Cycle through runes and print them into the whitespace.
+	// Cycle through characters and print them into the whitespace.
 	for i := 0; i < width; {
-		b.WriteRune(r[j])
+		b.WriteString(chars[j])
 		j++
-		if j >= len(r) {
+		if j >= len(chars) {
 			j = 0
 		}
-		i += ansi.PrintableRuneWidth(string(r[j]))
+		i += printableWidth(w.re, chars[j])
 	}
 
-	// Fill any extra gaps white spaces. This might be necessary if any runes
-	// are more than one cell wide, which could leave a one-rune gap.
-	short := width - ansi.PrintableRuneWidth(b.String())
+	// Fill any extra gaps white spaces. This might be necessary if any
+	// characters are more than one cell wide, which could leave a gap.
+	short := width - printableWidth(w.re, b.String())
 	if short > 0 {
 		b.WriteString(strings.Repeat(" ", short))
 	}
@@ -0,0 +1,269 @@
+package lipgloss
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+)
+
+// AmbiguousWidth is a policy for measuring characters whose East Asian width
+// is ambiguous, such as Greek and Cyrillic letters, box drawing characters
+// and some symbols. Terminals set up for East Asian languages usually show
+// them two cells wide, while others show them one cell wide.
+type AmbiguousWidth int
+
+// Available ambiguous width policies.
+const (
+	// AmbiguousWidthLocale measures ambiguous characters according to the
+	// locale: two cells wide in East Asian locales and one cell wide
+	// otherwise. The RUNEWIDTH_EASTASIAN environment variable, if set,
+	// takes precedence. This is the default.
+	AmbiguousWidthLocale AmbiguousWidth = iota
+
+	// AmbiguousWidthNarrow measures ambiguous characters one cell wide.
+	AmbiguousWidthNarrow
+
+	// AmbiguousWidthWide measures ambiguous characters two cells wide.
+	AmbiguousWidthWide
+)
+
+// String returns the name of the policy.
+func (a AmbiguousWidth) String() string {
+	switch a {
+	case AmbiguousWidthNarrow:
+		return "narrow"
+	case AmbiguousWidthWide:
+		return "wide"
+	default:
+		return "locale"
+	}
+}
+
+var (
+	narrowCondition = &runewidth.Condition{StrictEmojiNeutral: true}
+	wideCondition   = &runewidth.Condition{EastAsianWidth: true, StrictEmojiNeutral: true}
+)
+
+// AmbiguousWidth returns the renderer's policy for measuring characters of
+// ambiguous width.
+func (r *Renderer) AmbiguousWidth() AmbiguousWidth {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return r.ambiguousWidth
+}
+
+// SetAmbiguousWidth sets how characters whose East Asian width is ambiguous
+// are measured when rendering with the renderer. By default their width
+// depends on the locale. Set this to match the terminal being rendered to so
+// that borders, padding and alignment line up.
+//
+// This function is thread-safe.
+func (r *Renderer) SetAmbiguousWidth(a AmbiguousWidth) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.ambiguousWidth = a
+}
+
+// SetAmbiguousWidth sets how characters whose East Asian width is ambiguous
+// are measured by the default renderer, and so by Width, Size and the other
+// package-level functions.
+//
+// This function is thread-safe.
+func SetAmbiguousWidth(a AmbiguousWidth) {
+	renderer.SetAmbiguousWidth(a)
+}
+
+// widthCondition returns the runewidth condition for measuring characters
+// with the renderer. A nil renderer means the default renderer.
+func (r *Renderer) widthCondition() *runewidth.Condition {
+	if r == nil {
+		r = renderer
+	}
+	switch r.AmbiguousWidth() {
+	case AmbiguousWidthNarrow:
+		return narrowCondition
+	case AmbiguousWidthWide:
+		return wideCondition
+	default:
+		return runewidth.DefaultCondition
+	}
+}
+
+// clusterWidth returns the number of cells a grapheme cluster takes up.
+// That's the width of its first character that has any, except that flags
+// and characters followed by an emoji presentation selector are shown as
+// emoji, which are two cells wide.
+func clusterWidth(cond *runewidth.Condition, runes []rune) int {
+	if len(runes) > 1 {
+		if isRegionalIndicator(runes[0]) && isRegionalIndicator(runes[1]) {
+			return 2 //nolint:gomnd
+		}
+		for _, r := range runes[1:] {
+			if r == '\ufe0f' {
+				return 2 //nolint:gomnd
+			}
+		}
+	}
+	for _, r := range runes {
+		if w := cond.RuneWidth(r); w > 0 {
+			return w
+		}
+	}
+	return 0
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+// asciiWidth returns the width of an ASCII character.
+func asciiWidth(c byte) int {
+	if c < ' ' || c == 0x7f {
+		return 0
+	}
+	return 1
+}
+
+// isSimpleRune reports whether r is always a grapheme cluster of its own,
+// never combining with the characters around it. Text made up of such
+// characters, which covers most Latin text, punctuation, box drawing and
+// CJK, doesn't need to go through the more expensive grapheme cluster
+// segmentation.
+func isSimpleRune(r rune) bool {
+	switch {
+	case r < 0x300: // Latin
+		return true
+	case r >= 0x2010 && r <= 0x2027, r >= 0x2030 && r <= 0x205e: // punctuation
+		return true
+	case r >= 0x2070 && r <= 0x20cf: // super and subscripts, currency
+		return true
+	case r >= 0x2100 && r <= 0x2bff: // symbols, arrows, box drawing, shapes
+		return true
+	case r >= 0x4e00 && r <= 0x9fff: // CJK ideographs
+		return true
+	case r >= 0xac00 && r <= 0xd7a3: // Hangul syllables
+		return true
+	case r >= 0xff01 && r <= 0xff60, r >= 0xffe0 && r <= 0xffe6: // fullwidth forms
+		return true
+	}
+	return false
+}
+
+// isSimple reports whether str is made up of simple runes only. See
+// isSimpleRune.
+func isSimple(str string) bool {
+	for _, r := range str {
+		if !isSimpleRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// segmenter breaks a line of text into escape sequences and grapheme
+// clusters, which are what terminals lay out in cells: a letter along with
+// its combining marks, an emoji sequence joined with zero width joiners, a
+// flag, and so on.
+//
+//	seg := newSegmenter(r, str)
+//	for seg.next() {
+//		// Use seg.text, seg.width and seg.esc.
+//	}
+type segmenter struct {
+	r    *Renderer
+	cond *runewidth.Condition
+
+	// The rest of the line, the rest of the run of simple text being read,
+	// if any, and the clusters of the run of other text being read, if any.
+	str    string
+	simple string
+	g      *uniseg.Graphemes
+
+	// The current segment: its text, its width in cells and whether it's an
+	// escape sequence.
+	text  string
+	width int
+	esc   bool
+}
+
+// newSegmenter returns a segmenter for str, measuring with the given
+// renderer, or the default renderer if it's nil.
+func newSegmenter(r *Renderer, str string) segmenter {
+	return segmenter{r: r, str: str}
+}
+
+// next moves on to the next segment, returning false at the end of the line.
+func (s *segmenter) next() bool {
+	if s.simple != "" {
+		if c := s.simple[0]; c < utf8.RuneSelf {
+			s.text, s.width = s.simple[:1], asciiWidth(c)
+		} else {
+			r, n := utf8.DecodeRuneInString(s.simple)
+			s.text, s.width = s.simple[:n], s.condition().RuneWidth(r)
+		}
+		s.esc = false
+		s.simple = s.simple[len(s.text):]
+		return true
+	}
+	if s.g != nil {
+		if s.g.Next() {
+			s.text, s.width, s.esc = s.g.Str(), clusterWidth(s.condition(), s.g.Runes()), false
+			return true
+		}
+		s.g = nil
+	}
+	if s.str == "" {
+		return false
+	}
+
+	if s.str[0] == '\x1b' {
+		s.text, s.width, s.esc = readEscape(s.str), 0, true
+		s.str = s.str[len(s.text):]
+		return true
+	}
+
+	// Read up to the next escape sequence. Grapheme clusters don't span
+	// escape sequences.
+	run := s.str
+	if i := strings.IndexByte(s.str, '\x1b'); i >= 0 {
+		run = s.str[:i]
+	}
+	s.str = s.str[len(run):]
+	if isSimple(run) {
+		s.simple = run
+	} else {
+		s.g = uniseg.NewGraphemes(run)
+	}
+	return s.next()
+}
+
+// condition returns the runewidth condition to measure characters with,
+// looking it up the first time it's needed.
+func (s *segmenter) condition() *runewidth.Condition {
+	if s.cond == nil {
+		s.cond = s.r.widthCondition()
+	}
+	return s.cond
+}
+
+// graphemes splits text without escape sequences into grapheme clusters.
+func graphemes(str string) []string {
+	if isSimple(str) {
+		clusters := make([]string, 0, len(str))
+		for len(str) > 0 {
+			_, n := utf8.DecodeRuneInString(str)
+			clusters = append(clusters, str[:n])
+			str = str[n:]
+		}
+		return clusters
+	}
+
+	var clusters []string
+	g := uniseg.NewGraphemes(str)
+	for g.Next() {
+		clusters = append(clusters, g.Str())
+	}
+	return clusters
+}
@@ -0,0 +1,82 @@
+package lipgloss
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestAmbiguousWidth(t *testing.T) {
+	tt := []struct {
+		policy   AmbiguousWidth
+		input    string
+		expected int
+	}{
+		{AmbiguousWidthNarrow, "αβγ", 3},
+		{AmbiguousWidthWide, "αβγ", 6},
+		{AmbiguousWidthNarrow, "abc", 3},
+		{AmbiguousWidthWide, "abc", 3},
+		{AmbiguousWidthNarrow, "你好", 4},
+		{AmbiguousWidthWide, "你好", 4},
+	}
+
+	for i, tc := range tt {
+		r := NewRenderer(io.Discard)
+		r.SetAmbiguousWidth(tc.policy)
+		if r.AmbiguousWidth() != tc.policy {
+			t.Errorf("Test %d, expected policy %s, got %s", i, tc.policy, r.AmbiguousWidth())
+		}
+		if w := printableWidth(r, tc.input); w != tc.expected {
+			t.Errorf("Test %d (%s), expected %q to be %d cells wide, got %d",
+				i, tc.policy, tc.input, tc.expected, w)
+		}
+	}
+}
+
+func TestAmbiguousWidthRender(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetAmbiguousWidth(AmbiguousWidthWide)
+
+	res := r.NewStyle().Width(6).Render("αβ")
+	if expected := "αβ  "; res != expected {
+		t.Errorf("expected %q, got %q", expected, res)
+	}
+}
+
+func TestGraphemeBorders(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetAmbiguousWidth(AmbiguousWidthNarrow)
+
+	family := "\U0001F468\u200D\U0001F469\u200D\U0001F467"
+	res := r.NewStyle().Border(NormalBorder()).Render(family + "\n" + "e\u0301e\u0301")
+
+	expected := strings.Join([]string{
+		"┌──┐",
+		"│" + family + "│",
+		"│e\u0301e\u0301│",
+		"└──┘",
+	}, "\n")
+	if res != expected {
+		t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, res)
+	}
+}
+
+func TestGraphemes(t *testing.T) {
+	tt := []struct {
+		input    string
+		expected []string
+	}{
+		{"", nil},
+		{"ab", []string{"a", "b"}},
+		{"─│", []string{"─", "│"}},
+		{"e\u0301x", []string{"e\u0301", "x"}},
+		{"\U0001F1FA\U0001F1F8\U0001F1EC\U0001F1E7", []string{"\U0001F1FA\U0001F1F8", "\U0001F1EC\U0001F1E7"}},
+	}
+
+	for i, tc := range tt {
+		res := graphemes(tc.input)
+		if strings.Join(res, "|") != strings.Join(tc.expected, "|") || len(res) != len(tc.expected) {
+			t.Errorf("Test %d, expected %q, got %q", i, tc.expected, res)
+		}
+	}
+}
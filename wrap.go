@@ -0,0 +1,380 @@
+package lipgloss
+
+import (
+	"strings"
+	"unicode"
+)
+
+// softHyphen marks a place where a word may be hyphenated. It's invisible
+// unless the word is broken there.
+const softHyphen = "\u00ad"
+
+// wrapOptions configures wrapText.
+type wrapOptions struct {
+	// Hyphenate words when breaking them: at soft hyphens where possible,
+	// and anywhere in words too long to fit on a line of their own.
+	hyphenate bool
+
+	// Widen the spaces between words so that every line broken by wrapping
+	// fills the width. Lines ending a paragraph are left as they are.
+	justify bool
+}
+
+// wrapText wraps str to the given width, breaking lines between words where
+// it can and within words where it must. Spaces at a break are dropped.
+//
+// Styling and hyperlinks active at the end of a line are closed there and
+// reopened at the start of the next, so that every line can be padded,
+// aligned and bordered on its own without colors bleeding into the
+// surroundings.
+func wrapText(r *Renderer, str string, width int, opts wrapOptions) string {
+	if width <= 0 {
+		return str
+	}
+
+	w := wrapper{r: r, width: width, wrapOptions: opts}
+	for i, line := range strings.Split(str, "\n") {
+		if i > 0 {
+			w.newline(false)
+		}
+		w.wrapLine(line)
+	}
+	w.b.WriteString(w.line.String())
+
+	return w.b.String()
+}
+
+// wrapCell is a single printable character, that is a grapheme cluster,
+// along with any escape sequences preceding it. Cells holding only escape
+// sequences have no text.
+type wrapCell struct {
+	esc   []string
+	text  string
+	width int
+}
+
+func (c wrapCell) isSpace() bool {
+	return c.text != "" && unicode.IsSpace([]rune(c.text)[0])
+}
+
+// cellsWidth returns the total width of the given cells.
+func cellsWidth(cells []wrapCell) (width int) {
+	for _, c := range cells {
+		width += c.width
+	}
+	return width
+}
+
+// wrapper holds the state of a wrapping operation.
+type wrapper struct {
+	wrapOptions
+
+	r      *Renderer
+	b      strings.Builder
+	line   strings.Builder
+	state  ansiState
+	width  int
+	lineW  int
+	forced bool // whether the current line was started by breaking a word
+}
+
+// newline ends the current line, carrying the active styling over to the
+// next one. Soft line breaks are the ones made by wrapping, rather than
+// those in the input.
+func (w *wrapper) newline(soft bool) {
+	w.line.WriteString(w.state.close())
+	line := w.line.String()
+	if soft && w.justify {
+		line = justifyLine(w.r, line, w.width)
+	}
+	w.b.WriteString(line)
+	w.b.WriteByte('\n')
+
+	w.line.Reset()
+	w.line.WriteString(w.state.open())
+	w.lineW = 0
+	w.forced = false
+}
+
+// skip drops a cell, keeping only its escape sequences.
+func (w *wrapper) skip(c wrapCell) {
+	for _, seq := range c.esc {
+		w.state.update(seq)
+		w.line.WriteString(seq)
+	}
+}
+
+// place writes a cell, breaking the line first if the cell doesn't fit.
+func (w *wrapper) place(c wrapCell) {
+	if w.hyphenate && c.text == softHyphen {
+		w.skip(c)
+		return
+	}
+
+	if c.width > 0 && w.lineW > 0 && w.lineW+c.width > w.width {
+		w.newline(true)
+		w.forced = true
+	}
+	if w.lineW == 0 && w.forced && c.isSpace() {
+		w.skip(c)
+		return
+	}
+
+	w.skip(c)
+	w.line.WriteString(c.text)
+	w.lineW += c.width
+	if c.width > 0 {
+		w.forced = false
+	}
+}
+
+// placeAll writes the given cells, breaking lines wherever needed.
+func (w *wrapper) placeAll(cells []wrapCell) {
+	for _, c := range cells {
+		w.place(c)
+	}
+}
+
+// skipAll drops the given cells, keeping only their escape sequences.
+func (w *wrapper) skipAll(cells []wrapCell) {
+	for _, c := range cells {
+		w.skip(c)
+	}
+}
+
+// wrapLine wraps a single line of text, which must not contain newlines.
+func (w *wrapper) wrapLine(line string) {
+	var spaces, word []wrapCell
+
+	flush := func() {
+		sw, ww := cellsWidth(spaces), cellsWidth(word)
+
+		switch {
+		case w.lineW+sw+ww <= w.width:
+			w.placeAll(spaces)
+			w.placeAll(word)
+		case w.hyphenate && ww > 0:
+			w.breakWord(spaces, word)
+		case w.lineW > 0 || ww == 0:
+			// The word doesn't fit, so move it to the next line, dropping
+			// the spaces in between.
+			w.skipAll(spaces)
+			if w.lineW > 0 && ww > 0 {
+				w.newline(true)
+			}
+			w.placeAll(word)
+		case ww <= w.width:
+			// The indentation leaves no room for the word, which fits on a
+			// line of its own, so the word goes on the next line instead.
+			w.skipAll(spaces)
+			w.newline(true)
+			w.placeAll(word)
+		default:
+			// Indentation at the start of a line is kept and broken like
+			// anything else.
+			w.placeAll(spaces)
+			w.placeAll(word)
+		}
+
+		spaces, word = nil, nil
+	}
+
+	for _, c := range splitCells(w.r, line) {
+		switch {
+		case c.isSpace():
+			if len(word) > 0 {
+				flush()
+			}
+			spaces = append(spaces, c)
+		default:
+			word = append(word, c)
+			// Hyphens are a good place to break a line, too.
+			if c.text == "-" {
+				flush()
+			}
+		}
+	}
+
+	// Spaces ending a line would throw off its alignment, so they're
+	// dropped, unless there's nothing else on the line.
+	if len(word) == 0 && w.lineW > 0 {
+		w.skipAll(spaces)
+		spaces = nil
+	}
+	flush()
+}
+
+// breakWord places a word that doesn't fit on the current line, hyphenating
+// it at soft hyphens where possible. Words too long to fit on a line of
+// their own are hyphenated wherever they need to be.
+func (w *wrapper) breakWord(spaces, word []wrapCell) {
+	for {
+		// Leave room for the hyphen.
+		avail := w.width - w.lineW - cellsWidth(spaces) - 1
+		if cellsWidth(word) <= avail+1 {
+			w.placeAll(spaces)
+			w.placeAll(word)
+			return
+		}
+
+		cut := softHyphenCut(word, avail)
+		if cut < 0 && cellsWidth(word) > w.width {
+			cut = hardCut(word, avail)
+		}
+
+		switch {
+		case cut > 0:
+			w.placeAll(spaces)
+			w.placeAll(word[:cut])
+			w.line.WriteString("-")
+			w.lineW++
+			w.newline(true)
+
+			// The soft hyphen we broke at, if any, has served its purpose.
+			word = word[cut:]
+			if word[0].text == softHyphen {
+				w.skip(word[0])
+				word = word[1:]
+			}
+			spaces = nil
+		case w.lineW > 0 || len(spaces) > 0 && cellsWidth(word) <= w.width:
+			// The word goes on the next line, leaving behind the spaces
+			// before it, even if they're indentation, as long as it fits
+			// there.
+			w.skipAll(spaces)
+			w.newline(true)
+			spaces = nil
+		default:
+			// Nothing fits, even on a line of its own, so break wherever we
+			// must.
+			w.placeAll(spaces)
+			w.placeAll(word)
+			return
+		}
+	}
+}
+
+// softHyphenCut returns the index of the last soft hyphen in word that has
+// no more than width cells before it, or -1 if there isn't one.
+func softHyphenCut(word []wrapCell, width int) int {
+	cut := -1
+	var current int
+	for i, c := range word {
+		if current > width {
+			break
+		}
+		if c.text == softHyphen && i > 0 {
+			cut = i
+		}
+		current += c.width
+	}
+	return cut
+}
+
+// hardCut returns the index at which to hyphenate word so that no more than
+// width cells come before it, or -1 if that would leave a fragment too short
+// to be worth hyphenating.
+func hardCut(word []wrapCell, width int) int {
+	const minFragment = 2
+
+	var current int
+	for i, c := range word {
+		if current+c.width > width {
+			if current < minFragment {
+				return -1
+			}
+			return i
+		}
+		current += c.width
+	}
+	return -1
+}
+
+// justifyLine widens the spaces between words so that line fills width.
+// Extra spaces go to the leftmost gaps first. Leading spaces are left alone.
+//
+// Lines are justified before they're styled, so padding spaces added here
+// are styled like any other spaces in the text.
+func justifyLine(r *Renderer, line string, width int) string {
+	extra := width - printableWidth(r, line)
+	if extra <= 0 {
+		return line
+	}
+
+	// Find the gaps between words, recording the index just past the last
+	// space of each.
+	var (
+		gaps     []int
+		gapEnd   = -1
+		seenWord bool
+	)
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\x1b':
+			i += len(readEscape(line[i:])) - 1
+		case line[i] == ' ':
+			if seenWord {
+				gapEnd = i + 1
+			}
+		default:
+			if gapEnd >= 0 {
+				gaps = append(gaps, gapEnd)
+				gapEnd = -1
+			}
+			seenWord = true
+		}
+	}
+	if len(gaps) == 0 {
+		// Nothing to widen, so pad the line to keep it from being aligned
+		// like the last line of a paragraph.
+		return line + strings.Repeat(" ", extra)
+	}
+
+	var (
+		b    strings.Builder
+		prev int
+	)
+	for i, g := range gaps {
+		n := extra / len(gaps)
+		if i < extra%len(gaps) {
+			n++
+		}
+		b.WriteString(line[prev:g])
+		b.WriteString(strings.Repeat(" ", n))
+		prev = g
+	}
+	b.WriteString(line[prev:])
+
+	return b.String()
+}
+
+// splitCells breaks a line of text into cells, one for each grapheme
+// cluster. Zero-width characters are merged into the preceding cell, except
+// for soft hyphens, which get a cell of their own.
+func splitCells(r *Renderer, line string) []wrapCell {
+	var (
+		cells []wrapCell
+		esc   []string
+		seg   = newSegmenter(r, line)
+	)
+
+	for seg.next() {
+		if seg.esc {
+			esc = append(esc, seg.text)
+			continue
+		}
+
+		if seg.width == 0 && len(cells) > 0 && len(esc) == 0 && seg.text != softHyphen {
+			cells[len(cells)-1].text += seg.text
+			continue
+		}
+		cells = append(cells, wrapCell{esc: esc, text: seg.text, width: seg.width})
+		esc = nil
+	}
+
+	if len(esc) > 0 {
+		cells = append(cells, wrapCell{esc: esc})
+	}
+
+	return cells
+}
@@ -0,0 +1,242 @@
+package lipgloss
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestWrapText(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    string
+		width    int
+		expected string
+	}{
+		{
+			name:     "words",
+			input:    "the quick brown fox",
+			width:    10,
+			expected: "the quick\nbrown fox",
+		},
+		{
+			name:     "long word",
+			input:    "a verylongword",
+			width:    5,
+			expected: "a\nveryl\nongwo\nrd",
+		},
+		{
+			name:     "hyphen",
+			input:    "well-known",
+			width:    6,
+			expected: "well-\nknown",
+		},
+		{
+			name:     "newlines",
+			input:    "foo bar\nbaz",
+			width:    3,
+			expected: "foo\nbar\nbaz",
+		},
+		{
+			name:     "wide",
+			input:    "你好 世界",
+			width:    4,
+			expected: "你好\n世界",
+		},
+		{
+			name:     "grapheme clusters",
+			input:    "\U0001F1FA\U0001F1F8\U0001F1EC\U0001F1E7\U0001F1EB\U0001F1F7 e\u0301te\u0301",
+			width:    4,
+			expected: "\U0001F1FA\U0001F1F8\U0001F1EC\U0001F1E7\n\U0001F1EB\U0001F1F7\ne\u0301te\u0301",
+		},
+		{
+			name:     "style carried over",
+			input:    "\x1b[31mfoo bar\x1b[0m",
+			width:    3,
+			expected: "\x1b[31mfoo\x1b[0m\n\x1b[31mbar\x1b[0m",
+		},
+		{
+			name:     "hyperlink carried over",
+			input:    "\x1b]8;;https://charm.sh\x1b\\foo bar\x1b]8;;\x1b\\",
+			width:    3,
+			expected: "\x1b]8;;https://charm.sh\x1b\\foo\x1b]8;;\x1b\\\n\x1b]8;;https://charm.sh\x1b\\bar\x1b]8;;\x1b\\",
+		},
+		{
+			name:     "style across newline",
+			input:    "\x1b[1mfoo\nbar\x1b[0m",
+			width:    5,
+			expected: "\x1b[1mfoo\x1b[0m\n\x1b[1mbar\x1b[0m",
+		},
+		{
+			name:     "indented word",
+			input:    "  lead",
+			width:    4,
+			expected: "\nlead",
+		},
+		{
+			name:     "indentation kept",
+			input:    "  ab cd",
+			width:    4,
+			expected: "  ab\ncd",
+		},
+		{
+			name:     "trailing spaces",
+			input:    "foo   \nbar baz  ",
+			width:    5,
+			expected: "foo\nbar\nbaz",
+		},
+		{
+			name:     "only spaces",
+			input:    "   ",
+			width:    5,
+			expected: "   ",
+		},
+	}
+
+	for i, tc := range tt {
+		res := wrapText(nil, tc.input, tc.width, wrapOptions{})
+		if res != tc.expected {
+			t.Errorf("Test %d (%s), expected:\n\n`%s`\n\nActual output:\n\n`%s`\n\n",
+				i, tc.name, formatEscapes(tc.expected), formatEscapes(res))
+		}
+	}
+}
+
+func TestWrapTextOptions(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    string
+		width    int
+		opts     wrapOptions
+		expected string
+	}{
+		{
+			name:     "soft hyphens",
+			input:    "an extra\u00adordinary day",
+			width:    10,
+			opts:     wrapOptions{hyphenate: true},
+			expected: "an extra-\nordinary\nday",
+		},
+		{
+			name:     "unused soft hyphens",
+			input:    "extra\u00adordinary",
+			width:    20,
+			opts:     wrapOptions{hyphenate: true},
+			expected: "extraordinary",
+		},
+		{
+			name:     "long word",
+			input:    "a verylongword",
+			width:    6,
+			opts:     wrapOptions{hyphenate: true},
+			expected: "a ver-\nylong-\nword",
+		},
+		{
+			name:     "short fragment",
+			input:    "abc verylongword",
+			width:    6,
+			opts:     wrapOptions{hyphenate: true},
+			expected: "abc\nveryl-\nongwo-\nrd",
+		},
+		{
+			name:     "justify",
+			input:    "the quick brown fox jumps over\nthe lazy dog",
+			width:    16,
+			opts:     wrapOptions{justify: true},
+			expected: "the  quick brown\nfox jumps over\nthe lazy dog",
+		},
+		{
+			name:     "justify styled",
+			input:    "\x1b[1mab cd ef\x1b[0m",
+			width:    6,
+			opts:     wrapOptions{justify: true},
+			expected: "\x1b[1mab  cd\x1b[0m\n\x1b[1mef\x1b[0m",
+		},
+		{
+			name:     "justify single word",
+			input:    "abc defghi",
+			width:    7,
+			opts:     wrapOptions{justify: true},
+			expected: "abc    \ndefghi",
+		},
+		{
+			name:     "indented word",
+			input:    "  lead",
+			width:    4,
+			opts:     wrapOptions{hyphenate: true},
+			expected: "\nlead",
+		},
+	}
+
+	for i, tc := range tt {
+		res := wrapText(nil, tc.input, tc.width, tc.opts)
+		if res != tc.expected {
+			t.Errorf("Test %d (%s), expected:\n\n`%s`\n\nActual output:\n\n`%s`\n\n",
+				i, tc.name, formatEscapes(tc.expected), formatEscapes(res))
+		}
+	}
+}
+
+func TestRenderJustify(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.Ascii)
+
+	res := r.NewStyle().
+		Width(14).
+		Padding(0, 1).
+		Justify(true).
+		Align(Right).
+		Render("lip gloss makes terminals pretty")
+
+	expected := strings.Join([]string{
+		" lip    gloss ",
+		" makes        ",
+		" terminals    ",
+		"       pretty ",
+	}, "\n")
+
+	if res != expected {
+		t.Errorf("Expected:\n\n`%s`\n\nActual output:\n\n`%s`\n\n", expected, res)
+	}
+}
+
+func TestRenderWrapAlign(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.Ascii)
+
+	res := r.NewStyle().
+		Width(8).
+		Align(Right).
+		Render("hello   world   ")
+
+	expected := strings.Join([]string{
+		"   hello",
+		"   world",
+	}, "\n")
+
+	if res != expected {
+		t.Errorf("Expected:\n\n`%s`\n\nActual output:\n\n`%s`\n\n", expected, res)
+	}
+}
+
+func TestRenderPreStyled(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.TrueColor)
+
+	inner := r.NewStyle().Foreground(Color("#ff0000")).Render("foo bar")
+	res := r.NewStyle().Width(3).Border(NormalBorder()).Render(inner)
+
+	expected := strings.Join([]string{
+		"┌───┐",
+		"│\x1b[38;2;255;0;0mfoo\x1b[0m│",
+		"│\x1b[38;2;255;0;0mbar\x1b[0m│",
+		"└───┘",
+	}, "\n")
+
+	if res != expected {
+		t.Errorf("Expected:\n\n`%s`\n\nActual output:\n\n`%s`\n\n",
+			formatEscapes(expected), formatEscapes(res))
+	}
+}